@@ -0,0 +1,38 @@
+package chess
+
+// Returns every piece of colour that attacks loc. Sliding attacks are blocked
+// by the squares in occupied, which is normally b.Occupied(BOTH).
+func (b *BoardState) Attackers(loc Shift, colour Colour, occupied BitBoard) BitBoard {
+	square := BitBoard(1) << loc
+
+	// a pawn attacks loc if it sits diagonally behind it.
+	var pawns BitBoard
+	if colour == WHITE {
+		pawns = square.SouthEast() | square.SouthWest()
+	} else {
+		pawns = square.NorthEast() | square.NorthWest()
+	}
+
+	queens := b.GetPieces(colour, QUEEN)
+	attackers := pawns & b.GetPieces(colour, PAWN)
+	attackers |= KnightAttacks(loc) & b.GetPieces(colour, KNIGHT)
+	attackers |= KingAttacks(loc) & b.GetPieces(colour, KING)
+	attackers |= GetBishopAttack(loc, occupied) & (b.GetPieces(colour, BISHOP) | queens)
+	attackers |= GetRookAttack(loc, occupied) & (b.GetPieces(colour, ROOK) | queens)
+	return attackers
+}
+
+// Returns true if loc is attacked by any piece of colour.
+func (b *BoardState) IsAttacked(loc Shift, colour Colour) bool {
+	return b.Attackers(loc, colour, b.Occupied(BOTH)) != 0
+}
+
+// Returns true if the king of the player to move is in check.
+func (b *BoardState) InCheck() bool {
+	turn := b.Turn()
+	king := b.GetPieces(turn, KING)
+	if king == 0 {
+		return false
+	}
+	return b.IsAttacked(king.LSB(), 1-turn)
+}
@@ -0,0 +1,141 @@
+package chess
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+// positions used by the benchmarks, each with a move that can be played in it.
+var benchPositions = []struct {
+	fen  string
+	move string
+}{
+	{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "e2e4"},
+	{"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1", "e1g1"},
+	{"8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1", "e2e4"},
+	{"r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1", "c4c5"},
+	{"rnbq1k1r/pp1Pbppp/2p5/8/2B5/8/PPP1NnPP/RNBQK2R w KQ - 1 8", "d7c8q"},
+	{"r4rk1/1pp1qppp/p1np1n2/2b1p1B1/2B1P1b1/P1NP1N2/1PP1QPPP/R4RK1 w - - 0 10", "c3d5"},
+}
+
+func benchBoards(b *testing.B) ([]*BoardState, []Move) {
+	boards := []*BoardState{}
+	moves := []Move{}
+	for _, position := range benchPositions {
+		board, err := NewBoardFEN(position.fen)
+		if err != nil {
+			b.Fatal(err)
+		}
+		m, err := NewMoveUCI(position.move)
+		if err != nil {
+			b.Fatal(err)
+		}
+		boards = append(boards, board)
+		moves = append(moves, *m)
+	}
+	return boards, moves
+}
+
+func benchOccupancies() []BitBoard {
+	r := rand.New(rand.NewPCG(1, 2))
+	occupancies := make([]BitBoard, 1024)
+	for i := range occupancies {
+		occupancies[i] = BitBoard(r.Uint64() & r.Uint64())
+	}
+	return occupancies
+}
+
+func BenchmarkRookAttack(b *testing.B) {
+	occupancies := benchOccupancies()
+	var sink BitBoard
+	b.ResetTimer()
+	for i := range b.N {
+		sink ^= GetRookAttack(Shift(i%SHIFT_SIZE), occupancies[i%len(occupancies)])
+	}
+	_ = sink
+}
+
+func BenchmarkBishopAttack(b *testing.B) {
+	occupancies := benchOccupancies()
+	var sink BitBoard
+	b.ResetTimer()
+	for i := range b.N {
+		sink ^= GetBishopAttack(Shift(i%SHIFT_SIZE), occupancies[i%len(occupancies)])
+	}
+	_ = sink
+}
+
+func BenchmarkAttackers(b *testing.B) {
+	boards, _ := benchBoards(b)
+	var sink BitBoard
+	b.ResetTimer()
+	for i := range b.N {
+		board := boards[i%len(boards)]
+		sink ^= board.Attackers(Shift(i%SHIFT_SIZE), board.Turn(), board.Occupied(BOTH))
+	}
+	_ = sink
+}
+
+func BenchmarkInCheck(b *testing.B) {
+	boards, _ := benchBoards(b)
+	b.ResetTimer()
+	for i := range b.N {
+		boards[i%len(boards)].InCheck()
+	}
+}
+
+// copying the board back is the unmake.
+func BenchmarkMakeMove(b *testing.B) {
+	boards, moves := benchBoards(b)
+	b.ResetTimer()
+	for i := range b.N {
+		board := *boards[i%len(boards)]
+		if err := board.MakeMove(moves[i%len(moves)]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkHash(b *testing.B) {
+	boards, _ := benchBoards(b)
+	var sink uint64
+	b.ResetTimer()
+	for i := range b.N {
+		sink ^= boards[i%len(boards)].Hash()
+	}
+	_ = sink
+}
+
+func BenchmarkNewBoardFEN(b *testing.B) {
+	for i := range b.N {
+		if _, err := NewBoardFEN(benchPositions[i%len(benchPositions)].fen); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkFEN(b *testing.B) {
+	boards, _ := benchBoards(b)
+	b.ResetTimer()
+	for i := range b.N {
+		boards[i%len(boards)].FEN()
+	}
+}
+
+func BenchmarkGenerateLegal(b *testing.B) {
+	boards, _ := benchBoards(b)
+	var list MoveList
+	b.ResetTimer()
+	for i := range b.N {
+		list.Clear()
+		boards[i%len(boards)].GenerateLegal(&list)
+	}
+}
+
+func BenchmarkPerft(b *testing.B) {
+	board, _ := NewBoardFEN(benchPositions[1].fen)
+	b.ResetTimer()
+	for range b.N {
+		Perft(board, 3)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"iter"
+	"math/bits"
 	"slices"
 	"strconv"
 	"strings"
@@ -17,6 +19,7 @@ type BoardState struct {
 	pieces          [12]BitBoard //BitBoard, encoding for all pieces on board.
 	enpassant       BitBoard     //location of piece that can preform enpassant
 	encoding        uint8        //Encoding for castle and turn information.
+	castle_files    [4]uint8     //File of the rook for each castle right, in CASTLE_SYM order.
 	halfmove_clock  uint16       //Number of half moves since last pawn advance or piece capture, for 50 move rule.
 	fullmove_number uint16       //Number of full moves.
 }
@@ -38,6 +41,98 @@ func (b BitBoard) String() string {
 	return buffer.String()
 }
 
+// Returns the number of set squares.
+func (b BitBoard) PopCount() int {
+	return bits.OnesCount64(uint64(b))
+}
+
+// Returns the shift of the least significant set square, 64 if the board is empty.
+func (b BitBoard) LSB() Shift {
+	return Shift(bits.TrailingZeros64(uint64(b)))
+}
+
+// Returns the shift of the most significant set square, -1 if the board is empty.
+func (b BitBoard) MSB() Shift {
+	return Shift(63 - bits.LeadingZeros64(uint64(b)))
+}
+
+// Removes the least significant set square and returns its shift.
+func (b *BitBoard) PopLSB() Shift {
+	shift := b.LSB()
+	*b &= *b - 1
+	return shift
+}
+
+// Iterates over the shift of every set square from a1 to h8.
+func (b BitBoard) Squares() iter.Seq[Shift] {
+	return func(yield func(Shift) bool) {
+		// copied so the sequence can be ranged over more than once.
+		bb := b
+		for bb != 0 {
+			if !yield(bb.PopLSB()) {
+				return
+			}
+		}
+	}
+}
+
+// Moves every square one rank up, squares on rank 8 fall off.
+func (b BitBoard) North() BitBoard {
+	return b << ROW_COL_SIZE
+}
+
+// Moves every square one rank down, squares on rank 1 fall off.
+func (b BitBoard) South() BitBoard {
+	return b >> ROW_COL_SIZE
+}
+
+// Moves every square one file towards h, squares on the h file fall off.
+func (b BitBoard) East() BitBoard {
+	return (b &^ FILE_H) << 1
+}
+
+// Moves every square one file towards a, squares on the a file fall off.
+func (b BitBoard) West() BitBoard {
+	return (b &^ FILE_A) >> 1
+}
+
+func (b BitBoard) NorthEast() BitBoard {
+	return b.East().North()
+}
+
+func (b BitBoard) NorthWest() BitBoard {
+	return b.West().North()
+}
+
+func (b BitBoard) SouthEast() BitBoard {
+	return b.East().South()
+}
+
+func (b BitBoard) SouthWest() BitBoard {
+	return b.West().South()
+}
+
+// Fills every square north of a set square, including the square itself.
+func (b BitBoard) NorthFill() BitBoard {
+	b |= b << 8
+	b |= b << 16
+	b |= b << 32
+	return b
+}
+
+// Fills every square south of a set square, including the square itself.
+func (b BitBoard) SouthFill() BitBoard {
+	b |= b >> 8
+	b |= b >> 16
+	b |= b >> 32
+	return b
+}
+
+// Fills the whole file of every set square.
+func (b BitBoard) FileFill() BitBoard {
+	return b.NorthFill() | b.SouthFill()
+}
+
 // SquaresToBitBoard converts algebraic square strings (e.g. "a1", "e4") to a bitboard mask.
 func SquaresToBitBoard(squares []string) (BitBoard, error) {
 	var board BitBoard = 0
@@ -72,6 +167,7 @@ func NewBoardDefault() *BoardState {
 
 	//default encoding at the start of a chess game.
 	b.encoding |= TURN_MASK | WHITEOO_MASK | WHITEOOO_MASK | BLACKOO_MASK | BLACKOOO_MASK
+	b.castle_files = defaultCastleFiles()
 
 	//setting all move clocks to zero
 	b.halfmove_clock = 0
@@ -80,12 +176,16 @@ func NewBoardDefault() *BoardState {
 	return &b
 }
 
-// Generates a bit board from a FEN notation.
+// Generates a bit board from a FEN notation. The move clocks can be left off,
+// as in EPD, in which case they default to 0 and 1.
 func NewBoardFEN(FEN string) (*BoardState, error) {
 	b := BoardState{}
 
 	fields := strings.Fields(FEN)
 
+	if len(fields) == 4 {
+		fields = append(fields, "0", "1")
+	}
 	if len(fields) != 6 {
 		return nil, errors.New("invalid FEN")
 	}
@@ -96,19 +196,25 @@ func NewBoardFEN(FEN string) (*BoardState, error) {
 	}
 	var mask BitBoard = 1 << 63
 	for _, row := range rows {
+		files := 0
 		for i := len(row) - 1; i >= 0; i-- {
 			v := row[i]
 			idx := slices.Index(PICECES_SYM, string(v))
-			if idx != -1 {
+			switch {
+			case idx != -1:
 				b.pieces[idx] |= mask
 				mask = mask >> 1
-			} else {
-				mask = mask >> (v - 48)
+				files += 1
+			case v >= '1' && v <= '8':
+				mask = mask >> (v - '0')
+				files += int(v - '0')
+			default:
+				return nil, fmt.Errorf("invalid FEN, invalid piece %q", v)
 			}
 		}
-	}
-	if mask != 0 {
-		return nil, errors.New("invald FEN, piece placement invalid")
+		if files != ROW_COL_SIZE {
+			return nil, fmt.Errorf("invalid FEN, rank %s does not have 8 files", row)
+		}
 	}
 
 	b.encoding = 0
@@ -121,11 +227,8 @@ func NewBoardFEN(FEN string) (*BoardState, error) {
 	}
 
 	//castling
-	castle_info := fields[2]
-	for i, v := range CASTLE_SYM {
-		if strings.Contains(castle_info, v) {
-			b.encoding |= 1 << (i + 1)
-		}
+	if err := b.parseCastling(fields[2]); err != nil {
+		return nil, err
 	}
 
 	//Enpassant
@@ -157,6 +260,10 @@ func NewBoardFEN(FEN string) (*BoardState, error) {
 
 // Returns a string with turn, castle, enpassant and move number info
 func (b *BoardState) InfoString() string {
+	return b.infoString(false)
+}
+
+func (b *BoardState) infoString(shredder bool) string {
 	var buffer bytes.Buffer
 	//Turn information
 	buffer.WriteRune(' ')
@@ -168,16 +275,7 @@ func (b *BoardState) InfoString() string {
 
 	//Castle Information
 	buffer.WriteRune(' ')
-	canCastle := false
-	for i, v := range CASTLE_SYM {
-		if b.encoding&(1<<(i+1)) > 0 {
-			buffer.WriteString(v)
-			canCastle = true
-		}
-	}
-	if !canCastle {
-		buffer.WriteRune('-')
-	}
+	buffer.WriteString(b.castleString(shredder))
 
 	//Enpassant
 	buffer.WriteRune(' ')
@@ -196,36 +294,28 @@ func (b *BoardState) InfoString() string {
 	return buffer.String()
 }
 
-func (b *BoardState) toString(piceces []string) string {
-	boardStr := ""
-	for row := 7; row >= 0; row-- {
-		for col := 0; col < 8; col++ {
-			mask := BitBoard(1) << (uint(row*8 + col))
-			s := " _ "
-			for k, p := range b.pieces {
-				if mask&p > 0 {
-					s = " " + piceces[k] + " "
-					break
-				}
-			}
-			boardStr += s
-		}
-		boardStr += "\n"
-	}
-	boardStr += b.InfoString()
-	return boardStr
-}
-
 // Returns a string showing the location of every piece on the bord
 func (b *BoardState) String() string {
-	return b.toString(PICECES_SYM)
+	return b.RenderString(RenderOptions{Info: true})
 }
 
 func (b *BoardState) StringUni() string {
-	return b.toString(UNI_PICECES_SYM)
+	return b.RenderString(RenderOptions{Unicode: true, Info: true})
 }
 
+// Returns the FEN of the board. Castle rights use X-FEN, so they are written
+// as KQkq unless a right belongs to an inner rook.
 func (b *BoardState) FEN() string {
+	return b.placementString() + b.infoString(false)
+}
+
+// Returns the FEN of the board with Shredder-FEN castle rights, where every
+// right is written as the file of its rook.
+func (b *BoardState) ShredderFEN() string {
+	return b.placementString() + b.infoString(true)
+}
+
+func (b *BoardState) placementString() string {
 	var buffer bytes.Buffer
 
 	for row := 7; row >= 0; row-- {
@@ -260,13 +350,18 @@ func (b *BoardState) FEN() string {
 			buffer.WriteRune('/')
 		}
 	}
-	buffer.WriteString(b.InfoString())
 	return buffer.String()
 }
 
 // Returns a list of all legal moves from a current baord position
 func (b *BoardState) LegalMoves() []Move {
-	return make([]Move, 1) //TODO: get this working
+	var list MoveList
+	b.GenerateLegal(&list)
+	moves := make([]Move, list.Len())
+	for i, m := range list.Moves() {
+		moves[i] = m.Unpack()
+	}
+	return moves
 }
 
 // Returns a mask of every Occupied sqaure on the chess board.
@@ -294,3 +389,11 @@ func (b *BoardState) GetPieces(colour Colour, piece Piece) BitBoard {
 
 	return b.pieces[int(piece)+offset]
 }
+
+// Returns the colour of the player whose turn it is.
+func (b *BoardState) Turn() Colour {
+	if b.encoding&TURN_MASK > 0 {
+		return WHITE
+	}
+	return BLACK
+}
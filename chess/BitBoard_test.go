@@ -144,3 +144,88 @@ func TestSquaresToBitBoard(t *testing.T) {
 		}
 	})
 }
+
+func TestBitBoardBits(t *testing.T) {
+	b, _ := SquaresToBitBoard([]string{"b1", "e4", "g7"})
+	if b.PopCount() != 3 {
+		t.Errorf("expected popcount 3, got %d", b.PopCount())
+	}
+	if AlgFromLoc(1<<b.LSB()) != "b1" {
+		t.Errorf("expected LSB b1, got %s", AlgFromLoc(1<<b.LSB()))
+	}
+	if AlgFromLoc(1<<b.MSB()) != "g7" {
+		t.Errorf("expected MSB g7, got %s", AlgFromLoc(1<<b.MSB()))
+	}
+
+	squares := []string{}
+	for shift := range b.Squares() {
+		squares = append(squares, AlgFromLoc(1<<shift))
+	}
+	if len(squares) != 3 || squares[0] != "b1" || squares[1] != "e4" || squares[2] != "g7" {
+		t.Errorf("unexpected squares %v", squares)
+	}
+
+	seq := b.Squares()
+	for range 2 {
+		count := 0
+		for range seq {
+			count++
+		}
+		if count != 3 {
+			t.Errorf("expected 3 squares from every range over the sequence, got %d", count)
+		}
+	}
+
+	first := b.PopLSB()
+	if AlgFromLoc(1<<first) != "b1" || b.PopCount() != 2 {
+		t.Errorf("PopLSB returned %s and left %d squares", AlgFromLoc(1<<first), b.PopCount())
+	}
+
+	if EMPTY_BOARD.LSB() != 64 || EMPTY_BOARD.MSB() != -1 {
+		t.Error("unexpected LSB or MSB for empty board")
+	}
+}
+
+func TestBitBoardShifts(t *testing.T) {
+	tests := []struct {
+		name     string
+		shift    func(BitBoard) BitBoard
+		squares  []string
+		expected []string
+	}{
+		{"north", BitBoard.North, []string{"a1", "d4", "h8"}, []string{"a2", "d5"}},
+		{"south", BitBoard.South, []string{"a1", "d4", "h8"}, []string{"d3", "h7"}},
+		{"east", BitBoard.East, []string{"a1", "d4", "h8"}, []string{"b1", "e4"}},
+		{"west", BitBoard.West, []string{"a1", "d4", "h8"}, []string{"c4", "g8"}},
+		{"north east", BitBoard.NorthEast, []string{"a1", "h4"}, []string{"b2"}},
+		{"north west", BitBoard.NorthWest, []string{"a1", "h4"}, []string{"g5"}},
+		{"south east", BitBoard.SouthEast, []string{"a8", "h4"}, []string{"b7"}},
+		{"south west", BitBoard.SouthWest, []string{"a8", "h4"}, []string{"g3"}},
+		{"north fill", BitBoard.NorthFill, []string{"c6"}, []string{"c6", "c7", "c8"}},
+		{"south fill", BitBoard.SouthFill, []string{"c3"}, []string{"c3", "c2", "c1"}},
+		{"file fill", BitBoard.FileFill, []string{"e4"}, []string{"e1", "e2", "e3", "e4", "e5", "e6", "e7", "e8"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, _ := SquaresToBitBoard(test.squares)
+			expected, _ := SquaresToBitBoard(test.expected)
+			if got := test.shift(b); got != expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", expected.String(), got.String())
+			}
+		})
+	}
+}
+
+func TestNewBoardFENWithoutClocks(t *testing.T) {
+	b, err := NewBoardFEN("rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1"
+	if b.FEN() != expected {
+		t.Errorf("expected %s, got %s", expected, b.FEN())
+	}
+	if _, err := NewBoardFEN("rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0"); err == nil {
+		t.Error("expected error for FEN with 5 fields")
+	}
+}
@@ -0,0 +1,198 @@
+package chess
+
+import (
+	"fmt"
+)
+
+// Number of Chess960 start positions, index 518 is the standard setup.
+const CHESS960_POSITIONS = 960
+
+// Knight placements on the 5 squares left after the bishops and queen are
+// placed, indexed by the Scharnagl knight code.
+var chess960Knights = [10][2]int{
+	{0, 1}, {0, 2}, {0, 3}, {0, 4}, {1, 2},
+	{1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4},
+}
+
+// Returns a mask of the back rank of colour.
+func backRank(colour Colour) BitBoard {
+	if colour == WHITE {
+		return RANK_1
+	}
+	return RANK_8
+}
+
+// Returns the file of the king on its back rank, defaults to the e file when
+// the king is not on its back rank.
+func (b *BoardState) backRankKingFile(colour Colour) int {
+	king := b.GetPieces(colour, KING) & backRank(colour)
+	if king == 0 {
+		return 4
+	}
+	return int(king.LSB()) % ROW_COL_SIZE
+}
+
+// Returns the file of the outermost rook on the king or queen side of the king,
+// this is the rook that K and Q refer to in X-FEN. Defaults to the corner when
+// there is no rook.
+func (b *BoardState) outerRookFile(colour Colour, kingside bool) int {
+	rooks := b.GetPieces(colour, ROOK) & backRank(colour)
+	if colour == BLACK {
+		rooks >>= 56
+	}
+	king := b.backRankKingFile(colour)
+	if kingside {
+		for file := ROW_COL_SIZE - 1; file > king; file-- {
+			if rooks&(1<<file) > 0 {
+				return file
+			}
+		}
+		return ROW_COL_SIZE - 1
+	}
+	for file := 0; file < king; file++ {
+		if rooks&(1<<file) > 0 {
+			return file
+		}
+	}
+	return 0
+}
+
+// Parses the castle field of a FEN. Standard KQkq, X-FEN and Shredder-FEN
+// file letters are all accepted. Rights that can never be used, because the
+// king is not on its back rank or the file is the king's own, are dropped.
+func (b *BoardState) parseCastling(field string) error {
+	b.castle_files = defaultCastleFiles()
+	if field == "-" {
+		return nil
+	}
+
+	for _, c := range field {
+		var colour Colour
+		var file int
+		switch {
+		case c == 'K' || c == 'Q':
+			colour = WHITE
+			file = b.outerRookFile(colour, c == 'K')
+		case c == 'k' || c == 'q':
+			colour = BLACK
+			file = b.outerRookFile(colour, c == 'k')
+		case c >= 'A' && c <= 'H':
+			colour = WHITE
+			file = int(c - 'A')
+		case c >= 'a' && c <= 'h':
+			colour = BLACK
+			file = int(c - 'a')
+		default:
+			return fmt.Errorf("invalid FEN, invalid castle right %q", c)
+		}
+
+		if b.GetPieces(colour, KING)&backRank(colour) == 0 || file == b.backRankKingFile(colour) {
+			continue
+		}
+		i := int(colour) * 2
+		if file < b.backRankKingFile(colour) {
+			i += 1 //queen side
+		}
+		b.encoding |= 1 << (i + 1)
+		b.castle_files[i] = uint8(file)
+	}
+	return nil
+}
+
+// Returns the castle field of a FEN. With shredder set every right is written
+// as the file of its rook, otherwise K and Q are used for the outermost rooks.
+func (b *BoardState) castleString(shredder bool) string {
+	castle := ""
+	for i, v := range CASTLE_SYM {
+		if b.encoding&(1<<(i+1)) == 0 {
+			continue
+		}
+		colour := Colour(i / 2)
+		file := int(b.castle_files[i])
+		if !shredder && file == b.outerRookFile(colour, i%2 == 0) {
+			castle += v
+		} else if colour == WHITE {
+			castle += string(rune('A' + file))
+		} else {
+			castle += string(rune('a' + file))
+		}
+	}
+	if castle == "" {
+		return "-"
+	}
+	return castle
+}
+
+// Returns the square of the rook for a castle right, i is an index into
+// CASTLE_SYM. The bool is false if the right has been lost.
+func (b *BoardState) CastleRook(i int) (Shift, bool) {
+	if b.encoding&(1<<(i+1)) == 0 {
+		return 0, false
+	}
+	shift := Shift(b.castle_files[i])
+	if i >= 2 {
+		shift += 56
+	}
+	return shift, true
+}
+
+// New board with the Chess960 start position of the given index, using
+// Scharnagl numbering.
+func NewBoard960(index int) (*BoardState, error) {
+	if index < 0 || index >= CHESS960_POSITIONS {
+		return nil, fmt.Errorf("invalid Chess960 index %d", index)
+	}
+
+	var rank [ROW_COL_SIZE]Piece
+	placed := [ROW_COL_SIZE]bool{}
+	place := func(file int, piece Piece) {
+		rank[file] = piece
+		placed[file] = true
+	}
+	// places a piece on the nth empty square.
+	placeEmpty := func(n int, piece Piece) {
+		for file := range ROW_COL_SIZE {
+			if placed[file] {
+				continue
+			}
+			if n == 0 {
+				place(file, piece)
+				return
+			}
+			n--
+		}
+	}
+
+	n := index
+	place(2*(n%4)+1, BISHOP) //light squares
+	n /= 4
+	place(2*(n%4), BISHOP) //dark squares
+	n /= 4
+	placeEmpty(n%6, QUEEN)
+	n /= 6
+	knights := chess960Knights[n]
+	// the second knight is placed after the first so its index shifts by one.
+	placeEmpty(knights[0], KNIGHT)
+	placeEmpty(knights[1]-1, KNIGHT)
+	placeEmpty(0, ROOK)
+	placeEmpty(0, KING)
+	placeEmpty(0, ROOK)
+
+	b := BoardState{}
+	b.pieces[PAWN] = ROW_MASK << 8
+	b.pieces[PAWN+BLACK_OFFSET] = ROW_MASK << 48
+	rooks := []int{}
+	for file, piece := range rank {
+		b.pieces[piece] |= 1 << file
+		b.pieces[int(piece)+BLACK_OFFSET] |= 1 << (file + 56)
+		if piece == ROOK {
+			rooks = append(rooks, file)
+		}
+	}
+
+	b.encoding |= TURN_MASK | WHITEOO_MASK | WHITEOOO_MASK | BLACKOO_MASK | BLACKOOO_MASK
+	b.castle_files = [4]uint8{uint8(rooks[1]), uint8(rooks[0]), uint8(rooks[1]), uint8(rooks[0])}
+	b.fullmove_number = 1
+
+	return &b, nil
+}
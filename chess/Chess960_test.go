@@ -0,0 +1,117 @@
+package chess
+
+import (
+	"testing"
+)
+
+func TestNewBoard960(t *testing.T) {
+	tests := []struct {
+		index int
+		fen   string
+	}{
+		{518, "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1"},
+		{0, "bbqnnrkr/pppppppp/8/8/8/8/PPPPPPPP/BBQNNRKR w KQkq - 0 1"},
+		{959, "rkrnnqbb/pppppppp/8/8/8/8/PPPPPPPP/RKRNNQBB w KQkq - 0 1"},
+	}
+	for _, test := range tests {
+		b, err := NewBoard960(test.index)
+		if err != nil {
+			t.Fatalf("could not create position %d: %v", test.index, err)
+		}
+		if b.FEN() != test.fen {
+			t.Errorf("position %d, expected %s, got %s", test.index, test.fen, b.FEN())
+		}
+	}
+
+	seen := map[string]bool{}
+	for i := range CHESS960_POSITIONS {
+		b, err := NewBoard960(i)
+		if err != nil {
+			t.Fatalf("could not create position %d: %v", i, err)
+		}
+		fen := b.FEN()
+		if seen[fen] {
+			t.Errorf("duplicate position %d: %s", i, fen)
+		}
+		seen[fen] = true
+
+		bishops := b.GetPieces(WHITE, BISHOP)
+		const LIGHT_SQUARES BitBoard = 0x55AA55AA55AA55AA
+		if bishops&LIGHT_SQUARES == 0 || bishops&^LIGHT_SQUARES == 0 {
+			t.Errorf("position %d has bishops on the same colour", i)
+		}
+
+		queenside, _ := b.CastleRook(1)
+		kingside, _ := b.CastleRook(0)
+		king := b.backRankKingFile(WHITE)
+		if !(int(queenside) < king && king < int(kingside)) {
+			t.Errorf("position %d does not have the king between the rooks", i)
+		}
+
+		parsed, err := NewBoardFEN(b.ShredderFEN())
+		if err != nil {
+			t.Fatalf("could not parse %s: %v", b.ShredderFEN(), err)
+		}
+		if parsed.FEN() != fen {
+			t.Errorf("shredder round trip failed, expected %s, got %s", fen, parsed.FEN())
+		}
+	}
+
+	if _, err := NewBoard960(CHESS960_POSITIONS); err == nil {
+		t.Error("expected error for out of range index")
+	}
+}
+
+func TestCastleFEN(t *testing.T) {
+	tests := []struct {
+		input    string
+		fen      string
+		shredder string
+	}{
+		{
+			"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+			"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+			"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w HAha - 0 1",
+		},
+		{
+			"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w HAha - 0 1",
+			"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+			"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w HAha - 0 1",
+		},
+		{
+			// two rooks on the king side, the inner rook needs its file in X-FEN.
+			"1r3krr/pppppppp/8/8/8/8/PPPPPPPP/1R3KRR w GBgb - 0 1",
+			"1r3krr/pppppppp/8/8/8/8/PPPPPPPP/1R3KRR w GQgq - 0 1",
+			"1r3krr/pppppppp/8/8/8/8/PPPPPPPP/1R3KRR w GBgb - 0 1",
+		},
+		{
+			// the kings are not on their back ranks, so the rights are dropped.
+			"8/4k3/8/8/8/8/4K3/R6R w KQkq - 0 1",
+			"8/4k3/8/8/8/8/4K3/R6R w - - 0 1",
+			"8/4k3/8/8/8/8/4K3/R6R w - - 0 1",
+		},
+		{
+			// a right on the file of the king is dropped.
+			"r3k2r/8/8/8/8/8/8/R3K2R w EHe - 0 1",
+			"r3k2r/8/8/8/8/8/8/R3K2R w K - 0 1",
+			"r3k2r/8/8/8/8/8/8/R3K2R w H - 0 1",
+		},
+	}
+
+	for _, test := range tests {
+		b, err := NewBoardFEN(test.input)
+		if err != nil {
+			t.Fatalf("could not parse %s: %v", test.input, err)
+		}
+		if b.FEN() != test.fen {
+			t.Errorf("expected %s, got %s", test.fen, b.FEN())
+		}
+		if b.ShredderFEN() != test.shredder {
+			t.Errorf("expected %s, got %s", test.shredder, b.ShredderFEN())
+		}
+	}
+
+	if _, err := NewBoardFEN("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQxq - 0 1"); err == nil {
+		t.Error("expected error for invalid castle right")
+	}
+}
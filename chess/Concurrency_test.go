@@ -0,0 +1,77 @@
+package chess
+
+import (
+	"math/rand/v2"
+	"sync"
+	"testing"
+)
+
+// Reads the attack tables from many goroutines at once, run with -race to
+// check that nothing writes to them after the package is loaded.
+func TestConcurrentAttacks(t *testing.T) {
+	fens := []string{
+		"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+		"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1",
+		"8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1",
+		"3qk3/4P3/8/1B6/8/3n4/8/R3K3 b - - 0 1",
+	}
+
+	attackers := func(b *BoardState) []BitBoard {
+		result := []BitBoard{}
+		occupied := b.Occupied(BOTH)
+		for loc := range Shift(SHIFT_SIZE) {
+			for _, colour := range []Colour{WHITE, BLACK} {
+				result = append(result, b.Attackers(loc, colour, occupied))
+			}
+		}
+		return result
+	}
+
+	expected := [][]BitBoard{}
+	for _, fen := range fens {
+		b, err := NewBoardFEN(fen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected = append(expected, attackers(b))
+	}
+
+	const WORKERS = 16
+	var wg sync.WaitGroup
+	for worker := range WORKERS {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := rand.New(rand.NewPCG(uint64(worker), 0))
+			for i, fen := range fens {
+				b, err := NewBoardFEN(fen)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				for j, attack := range attackers(b) {
+					if attack != expected[i][j] {
+						t.Errorf("worker %d: attackers mismatch in %s", worker, fen)
+						return
+					}
+				}
+				b.InCheck()
+				b.Mirror().InCheck()
+				Perft(b, 2)
+
+				for loc := range Shift(SHIFT_SIZE) {
+					board := BitBoard(r.Uint64() & r.Uint64())
+					if GetQueenAttack(loc, board) != RayCast(loc, board, 0, ROOK_RAY)|RayCast(loc, board, 0, BISHOP_RAY) {
+						t.Errorf("worker %d: queen attack mismatch at %d", worker, loc)
+						return
+					}
+					KnightAttacks(loc)
+					KingAttacks(loc)
+					PawnAttacks(b.Turn(), loc)
+					PawnMoves(b.Turn(), loc)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
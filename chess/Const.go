@@ -87,6 +87,10 @@ const (
 const (
 	ROW_MASK    BitBoard = 255
 	COLUMN_MASK BitBoard = 72340172838076673
+	FILE_A      BitBoard = COLUMN_MASK
+	FILE_H      BitBoard = COLUMN_MASK << 7
+	RANK_1      BitBoard = ROW_MASK
+	RANK_8      BitBoard = ROW_MASK << 56
 )
 
 const (
@@ -99,7 +103,7 @@ const (
 	KING_OFFSET BitBoard = 0
 )
 
-// Use display_binary.py to conferm these numbers
+// Use the csv output of cmd/gentables to conferm these numbers
 // Mask is going to be a bit mask
 // Offset is always a shift number
 const (
@@ -124,3 +128,9 @@ var (
 	CASTLE_SYM      = []string{"K", "Q", "k", "q"}
 	COLUMNS         = []rune{'a', 'b', 'c', 'd', 'e', 'f', 'g', 'h'}
 )
+
+// Returns the rook files for each castle right in a standard game, in
+// CASTLE_SYM order.
+func defaultCastleFiles() [4]uint8 {
+	return [4]uint8{7, 0, 7, 0}
+}
@@ -0,0 +1,205 @@
+package chess
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// EPD is a position with a list of operations, as used by test suites such
+// as WAC and STS.
+type EPD struct {
+	Board      *BoardState
+	Operations []EPDOperation
+}
+
+// EPDOperation is an opcode and its operands, e.g. bm Nf3 Bc4. Quoted string
+// operands are stored without the quotes.
+type EPDOperation struct {
+	Opcode   string
+	Operands []string
+}
+
+// Parses an EPD record, the first 4 fields of a FEN followed by operations
+// that each end with a semicolon. The move clocks are taken from the hmvc and
+// fmvn operations if they are present.
+func NewEPD(record string) (*EPD, error) {
+	fields := strings.Fields(record)
+	if len(fields) < 4 {
+		return nil, errors.New("invalid EPD, not enough fields")
+	}
+
+	// the operations start after the 4th field.
+	rest := record
+	for range 4 {
+		rest = strings.TrimLeft(rest, " \t")
+		rest = rest[strings.IndexAny(rest+" ", " \t"):]
+	}
+
+	ops, err := parseEPDOperations(rest)
+	if err != nil {
+		return nil, err
+	}
+	e := &EPD{Operations: ops}
+
+	fen := strings.Join(fields[:4], " ")
+	hmvc, ok1 := e.Get("hmvc")
+	fmvn, ok2 := e.Get("fmvn")
+	if ok1 && ok2 && len(hmvc) == 1 && len(fmvn) == 1 {
+		fen += " " + hmvc[0] + " " + fmvn[0]
+	}
+	e.Board, err = NewBoardFEN(fen)
+	if err != nil {
+		return nil, fmt.Errorf("invalid EPD: %w", err)
+	}
+	return e, nil
+}
+
+func parseEPDOperations(s string) ([]EPDOperation, error) {
+	ops := []EPDOperation{}
+	tokens := []string{}
+	token := strings.Builder{}
+	quoted := false
+	inToken := false
+
+	endToken := func() {
+		if inToken {
+			tokens = append(tokens, token.String())
+			token.Reset()
+			inToken = false
+		}
+	}
+
+	for _, c := range s {
+		switch {
+		case quoted && c == '"':
+			quoted = false
+			endToken()
+		case quoted:
+			token.WriteRune(c)
+		case c == '"':
+			endToken()
+			quoted = true
+			inToken = true
+		case c == ';':
+			endToken()
+			if len(tokens) == 0 {
+				return nil, errors.New("invalid EPD, empty operation")
+			}
+			ops = append(ops, EPDOperation{tokens[0], tokens[1:]})
+			tokens = []string{}
+		case c == ' ' || c == '\t':
+			endToken()
+		default:
+			token.WriteRune(c)
+			inToken = true
+		}
+	}
+	if quoted {
+		return nil, errors.New("invalid EPD, unterminated string")
+	}
+	endToken()
+	if len(tokens) > 0 {
+		return nil, fmt.Errorf("invalid EPD, operation %s is missing a semicolon", tokens[0])
+	}
+	return ops, nil
+}
+
+// Returns the operands of the first operation with the opcode.
+func (e *EPD) Get(opcode string) ([]string, bool) {
+	for _, op := range e.Operations {
+		if op.Opcode == opcode {
+			return op.Operands, true
+		}
+	}
+	return nil, false
+}
+
+// Returns the id of the position, or an empty string if it has none.
+func (e *EPD) ID() string {
+	v, ok := e.Get("id")
+	if !ok || len(v) == 0 {
+		return ""
+	}
+	return v[0]
+}
+
+// Returns the best moves (bm) in SAN.
+func (e *EPD) BestMoves() []string {
+	v, _ := e.Get("bm")
+	return v
+}
+
+// Returns the moves to avoid (am) in SAN.
+func (e *EPD) AvoidMoves() []string {
+	v, _ := e.Get("am")
+	return v
+}
+
+// Returns the number of moves to a direct mate (dm), the bool is false if
+// the position has no dm operation.
+func (e *EPD) DirectMate() (int, bool) {
+	v, ok := e.Get("dm")
+	if !ok || len(v) != 1 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(v[0])
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// Returns the record as an EPD string.
+func (e *EPD) String() string {
+	fields := strings.Fields(e.Board.FEN())
+	var buffer strings.Builder
+	buffer.WriteString(strings.Join(fields[:4], " "))
+	for _, op := range e.Operations {
+		buffer.WriteRune(' ')
+		buffer.WriteString(op.Opcode)
+		for _, operand := range op.Operands {
+			buffer.WriteRune(' ')
+			if isEPDString(op.Opcode) || strings.ContainsAny(operand, " ;") {
+				buffer.WriteString("\"" + operand + "\"")
+			} else {
+				buffer.WriteString(operand)
+			}
+		}
+		buffer.WriteRune(';')
+	}
+	return buffer.String()
+}
+
+// Returns true for opcodes whose operands are strings, id and the comments c0
+// to c9.
+func isEPDString(opcode string) bool {
+	if opcode == "id" {
+		return true
+	}
+	return len(opcode) == 2 && opcode[0] == 'c' && opcode[1] >= '0' && opcode[1] <= '9'
+}
+
+// Reads every record of an EPD file, blank lines and lines starting with #
+// are skipped.
+func ReadEPD(r io.Reader) ([]*EPD, error) {
+	records := []*EPD{}
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		e, err := NewEPD(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		records = append(records, e)
+	}
+	return records, scanner.Err()
+}
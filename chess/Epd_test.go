@@ -0,0 +1,87 @@
+package chess
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNewEPD(t *testing.T) {
+	record := `2rr3k/pp3pp1/1nnqbN1p/3pN3/2pP4/2P3Q1/PPB4P/R4RK1 w - - bm Qg6; id "WAC.001"; c0 "mate; in 3";`
+	e, err := NewEPD(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.ID() != "WAC.001" {
+		t.Errorf("expected id WAC.001, got %q", e.ID())
+	}
+	if !slices.Equal(e.BestMoves(), []string{"Qg6"}) {
+		t.Errorf("unexpected best moves %v", e.BestMoves())
+	}
+	if comment, _ := e.Get("c0"); len(comment) != 1 || comment[0] != "mate; in 3" {
+		t.Errorf("unexpected comment %v", comment)
+	}
+	if e.Board.FEN() != "2rr3k/pp3pp1/1nnqbN1p/3pN3/2pP4/2P3Q1/PPB4P/R4RK1 w - - 0 1" {
+		t.Errorf("unexpected board %s", e.Board.FEN())
+	}
+	if e.String() != record {
+		t.Errorf("expected %s, got %s", record, e.String())
+	}
+}
+
+func TestEPDOperations(t *testing.T) {
+	e, err := NewEPD("r1b1k2r/ppp2ppp/8/8/8/8/PPP2PPP/R1B1K2R b KQkq - am O-O Bd7; bm Kf8 Ke7; dm 4; hmvc 12; fmvn 30;")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(e.AvoidMoves(), []string{"O-O", "Bd7"}) {
+		t.Errorf("unexpected avoid moves %v", e.AvoidMoves())
+	}
+	if !slices.Equal(e.BestMoves(), []string{"Kf8", "Ke7"}) {
+		t.Errorf("unexpected best moves %v", e.BestMoves())
+	}
+	if n, ok := e.DirectMate(); !ok || n != 4 {
+		t.Errorf("expected dm 4, got %d", n)
+	}
+	if !strings.HasSuffix(e.Board.FEN(), " b KQkq - 12 30") {
+		t.Errorf("move clocks not taken from hmvc and fmvn, got %s", e.Board.FEN())
+	}
+	if e.ID() != "" {
+		t.Errorf("expected no id, got %q", e.ID())
+	}
+
+	bad := []string{
+		"8/8/8/8/8/8/8/8 w -",
+		"k7/8/8/8/8/8/8/K7 w - - bm Kb2",
+		`k7/8/8/8/8/8/8/K7 w - - id "open;`,
+		"k7/8/8/8/8/8/8/K7 w - - ;",
+		"8/8/8/8/8/8/8/8 w - e bm x;",
+		"k7/8/8/8/8/8/8/K6x w - - bm Kb2;",
+	}
+	for _, record := range bad {
+		if _, err := NewEPD(record); err == nil {
+			t.Errorf("expected error for %s", record)
+		}
+	}
+}
+
+func TestReadEPD(t *testing.T) {
+	suite := strings.Join([]string{
+		"# a small suite",
+		`k7/8/8/8/8/8/8/K7 w - - bm Kb2; id "one";`,
+		"",
+		`k7/8/8/8/8/8/8/K7 b - - bm Kb7; id "two";`,
+	}, "\n")
+	records, err := ReadEPD(strings.NewReader(suite))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[0].ID() != "one" || records[1].ID() != "two" {
+		t.Errorf("unexpected records %v", records)
+	}
+
+	_, err = ReadEPD(strings.NewReader("k7/8/8/8/8/8/8/K7 w - - bm Kb2"))
+	if err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Errorf("expected error on line 1, got %v", err)
+	}
+}
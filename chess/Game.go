@@ -0,0 +1,220 @@
+package chess
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Game is a tree of positions from a start position. The moves played so far
+// are the path from the root to the current node, and every other branch is a
+// variation. The first child of a node is its main line.
+type Game struct {
+	root    *gameNode
+	current *gameNode
+}
+
+type gameNode struct {
+	move     Move //move that led to this position, unset for the root
+	board    BoardState
+	hash     uint64
+	ply      int
+	parent   *gameNode
+	children []*gameNode
+}
+
+// New game from the start position, the board is copied.
+func NewGame(start *BoardState) *Game {
+	root := &gameNode{board: *start, hash: start.Hash()}
+	return &Game{root, root}
+}
+
+// New game from a FEN.
+func NewGameFEN(FEN string) (*Game, error) {
+	b, err := NewBoardFEN(FEN)
+	if err != nil {
+		return nil, err
+	}
+	return NewGame(b), nil
+}
+
+// Returns a copy of the start position.
+func (g *Game) Start() *BoardState {
+	b := g.root.board
+	return &b
+}
+
+// Returns a copy of the current position.
+func (g *Game) Board() *BoardState {
+	b := g.current.board
+	return &b
+}
+
+// Returns the number of half moves from the start position.
+func (g *Game) Ply() int {
+	return g.current.ply
+}
+
+// Returns the hash of the current position.
+func (g *Game) Hash() uint64 {
+	return g.current.hash
+}
+
+// Plays a legal move from the current position. If the move has been played
+// from here before the existing branch is followed, otherwise it is added as a
+// new variation, or as the main line if there is none.
+func (g *Game) Push(m Move) error {
+	m, err := g.current.board.resolveMove(m)
+	if err != nil {
+		return err
+	}
+	var list MoveList
+	g.current.board.GenerateLegal(&list)
+	if !slices.Contains(list.Moves(), m.Pack()) {
+		return fmt.Errorf("illegal move %s", m.String())
+	}
+	for _, child := range g.current.children {
+		if child.move == m {
+			g.current = child
+			return nil
+		}
+	}
+
+	node := &gameNode{move: m, board: g.current.board, ply: g.current.ply + 1, parent: g.current}
+	node.board.applyMove(m)
+	node.hash = node.board.Hash()
+	g.current.children = append(g.current.children, node)
+	g.current = node
+	return nil
+}
+
+// Plays moves in UCI notation, as in the UCI position command. Stops at the
+// first move that can not be played.
+func (g *Game) PushUCI(moves ...string) error {
+	for _, uci := range moves {
+		m, err := NewMoveUCI(uci)
+		if err != nil {
+			return err
+		}
+		if err := g.Push(*m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Takes back the last move and returns it. The move stays in the tree so it
+// can be played again with Push or Jump. The bool is false at the start.
+func (g *Game) Pop() (Move, bool) {
+	if g.current.parent == nil {
+		return Move{}, false
+	}
+	m := g.current.move
+	g.current = g.current.parent
+	return m, true
+}
+
+// Goes to a ply of the current line. Plies before the current position go
+// back along the moves played, later plies follow the main line forward.
+func (g *Game) Jump(ply int) error {
+	if ply < 0 {
+		return fmt.Errorf("invalid ply %d", ply)
+	}
+	node := g.current
+	for node.ply > ply {
+		node = node.parent
+	}
+	for node.ply < ply {
+		if len(node.children) == 0 {
+			return fmt.Errorf("invalid ply %d, the line ends at ply %d", ply, node.ply)
+		}
+		node = node.children[0]
+	}
+	g.current = node
+	return nil
+}
+
+// Returns the moves played from the start position to the current position.
+func (g *Game) Moves() []Move {
+	moves := make([]Move, g.current.ply)
+	for node := g.current; node.parent != nil; node = node.parent {
+		moves[node.ply-1] = node.move
+	}
+	return moves
+}
+
+// Returns the main line from the current position to the end of the game.
+func (g *Game) MainLine() []Move {
+	moves := []Move{}
+	for node := g.current; len(node.children) > 0; node = node.children[0] {
+		moves = append(moves, node.children[0].move)
+	}
+	return moves
+}
+
+// Returns the moves played from the current position, the first is the main
+// line and the rest are variations.
+func (g *Game) Variations() []Move {
+	moves := make([]Move, len(g.current.children))
+	for i, child := range g.current.children {
+		moves[i] = child.move
+	}
+	return moves
+}
+
+// Makes the variation starting with the current move the main line of its
+// parent.
+func (g *Game) PromoteVariation() {
+	parent := g.current.parent
+	if parent == nil {
+		return
+	}
+	for i, child := range parent.children {
+		if child == g.current {
+			copy(parent.children[1:i+1], parent.children[:i])
+			parent.children[0] = child
+			return
+		}
+	}
+}
+
+// Removes the current move and every move after it from the tree and goes
+// back to the previous position.
+func (g *Game) DeleteVariation() {
+	parent := g.current.parent
+	if parent == nil {
+		return
+	}
+	for i, child := range parent.children {
+		if child == g.current {
+			parent.children = append(parent.children[:i], parent.children[i+1:]...)
+			break
+		}
+	}
+	g.current = parent
+}
+
+// Returns the hash of every position from the start to the current position.
+func (g *Game) Hashes() []uint64 {
+	hashes := make([]uint64, g.current.ply+1)
+	for node := g.current; node != nil; node = node.parent {
+		hashes[node.ply] = node.hash
+	}
+	return hashes
+}
+
+// Returns the number of times the current position has occurred before in
+// the game. Only positions since the last capture or pawn move can repeat.
+func (g *Game) Repetitions() int {
+	count := 0
+	node := g.current
+	for range g.current.board.halfmove_clock {
+		node = node.parent
+		if node == nil {
+			break
+		}
+		if node.hash == g.current.hash {
+			count++
+		}
+	}
+	return count
+}
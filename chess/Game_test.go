@@ -0,0 +1,158 @@
+package chess
+
+import (
+	"testing"
+)
+
+const START_FEN = "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1"
+
+func movesString(moves []Move) []string {
+	s := []string{}
+	for _, m := range moves {
+		s = append(s, m.String())
+	}
+	return s
+}
+
+func TestGamePushPop(t *testing.T) {
+	g, err := NewGameFEN(START_FEN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.PushUCI("e2e4", "e7e5", "g1f3"); err != nil {
+		t.Fatal(err)
+	}
+	if g.Ply() != 3 {
+		t.Errorf("expected ply 3, got %d", g.Ply())
+	}
+
+	m, ok := g.Pop()
+	if !ok || m.String() != "g1f3" {
+		t.Errorf("expected to pop g1f3, got %s", m.String())
+	}
+	if fen := g.Board().FEN(); fen != "rnbqkbnr/pppp1ppp/8/4p3/4P3/8/PPPP1PPP/RNBQKBNR w KQkq e6 0 2" {
+		t.Errorf("wrong position after pop %s", fen)
+	}
+
+	// a different move becomes a variation of g1f3.
+	if err := g.PushUCI("b1c3"); err != nil {
+		t.Fatal(err)
+	}
+	g.Pop()
+	if got := movesString(g.Variations()); len(got) != 2 || got[0] != "g1f3" || got[1] != "b1c3" {
+		t.Errorf("unexpected variations %v", got)
+	}
+
+	if err := g.Jump(0); err != nil {
+		t.Fatal(err)
+	}
+	if g.Board().FEN() != START_FEN {
+		t.Errorf("jump to the start gave %s", g.Board().FEN())
+	}
+	if _, ok := g.Pop(); ok {
+		t.Error("pop at the start should fail")
+	}
+	if got := movesString(g.MainLine()); len(got) != 3 || got[2] != "g1f3" {
+		t.Errorf("unexpected main line %v", got)
+	}
+
+	if err := g.Jump(3); err != nil {
+		t.Fatal(err)
+	}
+	if got := movesString(g.Moves()); len(got) != 3 || got[2] != "g1f3" {
+		t.Errorf("unexpected moves %v", got)
+	}
+	if err := g.Jump(4); err == nil {
+		t.Error("expected error jumping past the end of the line")
+	}
+
+	g.Pop()
+	g.PushUCI("b1c3")
+	g.PromoteVariation()
+	g.Pop()
+	if got := movesString(g.Variations()); got[0] != "b1c3" {
+		t.Errorf("expected b1c3 to be the main line, got %v", got)
+	}
+	g.PushUCI("b1c3")
+	g.DeleteVariation()
+	if got := movesString(g.Variations()); len(got) != 1 || got[0] != "g1f3" {
+		t.Errorf("expected only g1f3 after delete, got %v", got)
+	}
+
+	if err := g.PushUCI("e1e2", "e7e5"); err == nil {
+		t.Error("expected error for a move of a piece that is not there")
+	}
+
+	g, _ = NewGameFEN("4k3/8/8/8/8/8/8/R3K3 w - - 0 1")
+	for _, uci := range []string{"a1h8", "e1e3", "a1e8"} {
+		if err := g.PushUCI(uci); err == nil {
+			t.Errorf("expected error for illegal move %s", uci)
+		}
+	}
+	if g.Ply() != 0 {
+		t.Errorf("illegal moves should not be played, ply %d", g.Ply())
+	}
+}
+
+func TestGameRepetitions(t *testing.T) {
+	g, _ := NewGameFEN(START_FEN)
+	g.PushUCI("g1f3", "g8f6", "f3g1", "f6g8")
+	if g.Hash() != g.Hashes()[0] {
+		t.Error("expected the start position to hash the same after the knights return")
+	}
+	if g.Repetitions() != 1 {
+		t.Errorf("expected 1 repetition, got %d", g.Repetitions())
+	}
+	g.PushUCI("g1f3", "g8f6", "f3g1", "f6g8")
+	if g.Repetitions() != 2 {
+		t.Errorf("expected 2 repetitions, got %d", g.Repetitions())
+	}
+	g.PushUCI("e2e4")
+	if g.Repetitions() != 0 {
+		t.Errorf("expected no repetitions, got %d", g.Repetitions())
+	}
+}
+
+func TestHash(t *testing.T) {
+	// the same position by a different move order.
+	a, _ := NewGameFEN(START_FEN)
+	a.PushUCI("e2e4", "e7e6", "d2d4")
+	b, _ := NewGameFEN(START_FEN)
+	b.PushUCI("d2d4", "e7e6", "e2e4")
+	if a.Hash() != b.Hash() {
+		t.Error("transposition did not hash the same")
+	}
+
+	// an en passant square is only hashed when it can be captured.
+	x, _ := NewBoardFEN("4k3/8/8/8/4P3/8/8/4K3 b - e3 0 1")
+	y, _ := NewBoardFEN("4k3/8/8/8/4P3/8/8/4K3 b - - 0 1")
+	if x.Hash() != y.Hash() {
+		t.Error("unusable en passant square changed the hash")
+	}
+	x, _ = NewBoardFEN("4k3/8/8/8/3pP3/8/8/4K3 b - e3 0 1")
+	y, _ = NewBoardFEN("4k3/8/8/8/3pP3/8/8/4K3 b - - 0 1")
+	if x.Hash() == y.Hash() {
+		t.Error("en passant square did not change the hash")
+	}
+
+	x, _ = NewBoardFEN(START_FEN)
+	y, _ = NewBoardFEN("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR b KQkq - 0 1")
+	if x.Hash() == y.Hash() {
+		t.Error("turn did not change the hash")
+	}
+}
+
+func TestGameCastleSpellings(t *testing.T) {
+	g, _ := NewGameFEN("r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1")
+	if err := g.PushUCI("e1g1"); err != nil {
+		t.Fatal(err)
+	}
+	g.Pop()
+	if err := g.PushUCI("e1h1"); err != nil {
+		t.Fatal(err)
+	}
+	g.Pop()
+	if got := movesString(g.Variations()); len(got) != 1 || got[0] != "e1g1" {
+		t.Errorf("expected both spellings to find e1g1, got %v", got)
+	}
+}
@@ -1,39 +1,22 @@
 package chess
 
-import (
-	"errors"
-	"math/rand/v2"
-)
-
-// Magic limit
-const MAGIC_LIMIT = 1_000_000
+//go:generate go run ../cmd/gentables -out Tables.go
 
-// there are only 64 knight moves on a chess board
-// each index is the shift of the knight, the value is the attack
-var KNIGHT_ATTACKS []BitBoard
-var KING_ATTACKS []BitBoard
-
-// pawns are split up into attacks and move's
-// Black and white pecies are split up due to the fact that they are different for pawns.
+// The attack tables are built once when the package is loaded and never
+// written again, so they are safe to read from any number of goroutines.
+// The knight, king and pawn tables are the arrays in Tables.go, the slider
+// tables below are built from the magic numbers in Tables.go.
 var (
-	WHITE_PAWN_ATTACKS []BitBoard
-	WHITE_PAWN_MOVES   []BitBoard
-	BLACK_PAWN_ATTACKS []BitBoard
-	BLACK_PAWN_MOVES   []BitBoard
-)
-
-// sliding piececs
-var (
-	ROOK_MAGIC     []MagicEntry //magic numbers
-	BISHOP_MAGIC   []MagicEntry
-	ROOK_ATTTACKS  [][]BitBoard
-	BISHOP_ATTACKS [][]BitBoard
+	rookMagic     [SHIFT_SIZE]MagicEntry
+	bishopMagic   [SHIFT_SIZE]MagicEntry
+	rookAttacks   [SHIFT_SIZE][]BitBoard
+	bishopAttacks [SHIFT_SIZE][]BitBoard
 )
 
 type MagicEntry struct {
 	Mask  BitBoard
 	Magic uint64
-	Index Shift
+	Index Shift //number of bits in the table index
 }
 
 // array of vector that tell in which directions for the Ray caster to cast
@@ -51,16 +34,52 @@ func MagicIndex(entry MagicEntry, board BitBoard) uint64 {
 	return index
 }
 
+// there are only 64 knight moves on a chess board
+// each index is the shift of the knight, the value is the attack
+func KnightAttacks(loc Shift) BitBoard {
+	return knightAttacks[loc]
+}
+
+func KingAttacks(loc Shift) BitBoard {
+	return kingAttacks[loc]
+}
+
+// pawns are split up into attacks and move's
+// Black and white pecies are split up due to the fact that they are different for pawns.
+func PawnAttacks(colour Colour, loc Shift) BitBoard {
+	if colour == WHITE {
+		return whitePawnAttacks[loc]
+	}
+	return blackPawnAttacks[loc]
+}
+
+func PawnMoves(colour Colour, loc Shift) BitBoard {
+	if colour == WHITE {
+		return whitePawnMoves[loc]
+	}
+	return blackPawnMoves[loc]
+}
+
+func RookMagic(loc Shift) MagicEntry {
+	return rookMagic[loc]
+}
+
+func BishopMagic(loc Shift) MagicEntry {
+	return bishopMagic[loc]
+}
+
 func GetRookAttack(loc Shift, board BitBoard) BitBoard {
-	magic := ROOK_MAGIC[loc]
-	idx := MagicIndex(magic, board)
-	return ROOK_ATTTACKS[loc][idx]
+	idx := MagicIndex(rookMagic[loc], board)
+	return rookAttacks[loc][idx]
 }
 
 func GetBishopAttack(loc Shift, board BitBoard) BitBoard {
-	magic := BISHOP_MAGIC[loc]
-	idx := MagicIndex(magic, board)
-	return BISHOP_ATTACKS[loc][idx]
+	idx := MagicIndex(bishopMagic[loc], board)
+	return bishopAttacks[loc][idx]
+}
+
+func GetQueenAttack(loc Shift, board BitBoard) BitBoard {
+	return GetRookAttack(loc, board) | GetBishopAttack(loc, board)
 }
 
 func GetRookMask(coord Coordinates) BitBoard {
@@ -72,43 +91,36 @@ func GetBishopMask(coord Coordinates) BitBoard {
 	return RayCast(ShiftFromCoords(coord), 0, 0, BISHOP_RAY)
 }
 
-func FindMagic(coord Coordinates) ([]BitBoard, error) {
-	mask := GetRookMask(coord)
-	shift := ShiftFromCoords(coord)
-	for _ = range MAGIC_LIMIT {
-		test_magic := rand.Uint64() & rand.Uint64() & rand.Uint64()
-		magicE := MagicEntry{mask, test_magic, shift}
-		table, err := TryRookMagic(shift, magicE)
-		if err != nil {
-			continue
-		}
-		return table, nil
-	}
-	return nil, errors.New("hit magic limit, magic not found")
-
+// Returns the squares whose occupancy changes the attack of a slider on loc,
+// the edge of the board is left out since it never blocks anything.
+func GetMagicMask(loc Shift, r Ray) BitBoard {
+	coord := CoordsFromShift(loc)
+	row := ROW_MASK << (coord.row * ROW_COL_SIZE)
+	col := COLUMN_MASK << coord.col
+	edges := ((RANK_1 | RANK_8) &^ row) | ((FILE_A | FILE_H) &^ col)
+	return RayCast(loc, 0, 0, r) &^ edges
 }
 
-func TryRookMagic(loc Shift, magic MagicEntry) ([]BitBoard, error) {
-	table := make([]BitBoard, 1<<(64-magic.Index)) //TODO: this need to be check to see if its correct
-	var blockers BitBoard = 0
-	mask := magic.Mask
-
-	for true {
-		moves := RayCast(loc, blockers, mask, ROOK_RAY)
-		table_entry := &table[MagicIndex(magic, blockers)]
-		if *table_entry == 0 {
-			*table_entry = moves
-		} else if *table_entry != moves {
-			return nil, errors.New("invalid magic")
-		}
-
-		blockers = (blockers - mask) & mask
-		if blockers == 0 {
-			break
+// Builds the magic entries and attack tables of a slider from the magic
+// numbers made by gentables.
+func buildMagicTables(magics *[SHIFT_SIZE]uint64, r Ray, entries *[SHIFT_SIZE]MagicEntry, tables *[SHIFT_SIZE][]BitBoard) {
+	for loc := range Shift(SHIFT_SIZE) {
+		mask := GetMagicMask(loc, r)
+		entry := MagicEntry{mask, magics[loc], Shift(mask.PopCount())}
+		table := make([]BitBoard, 1<<entry.Index)
+
+		// walk every subset of the mask.
+		var blockers BitBoard = 0
+		for {
+			table[MagicIndex(entry, blockers)] = RayCast(loc, blockers, mask, r)
+			blockers = (blockers - mask) & mask
+			if blockers == 0 {
+				break
+			}
 		}
+		entries[loc] = entry
+		tables[loc] = table
 	}
-
-	return table, nil
 }
 
 func RayCast(inital Shift, blockers BitBoard, _ BitBoard, r Ray) BitBoard {
@@ -136,37 +148,7 @@ func RayCast(inital Shift, blockers BitBoard, _ BitBoard, r Ray) BitBoard {
 	return attacks
 }
 
-func BuildAllAttacks() {
-	BuildKnightAttacks()
-	BuildKingAttacks()
-	BuildPawnMoves()
-	BuildPawnAttacks()
-	//BuildRookAttacks()
-	//BuildBishopAttacks()
-	//BuildQueenAttacks()
-}
-
-func BuildKnightAttacks() {
-	file_name := "data/knight_attacks.csv"
-	KNIGHT_ATTACKS = LoadAttacks(file_name)
-}
-
-func BuildKingAttacks() {
-	file_name := "data/king_attacks.csv"
-	KING_ATTACKS = LoadAttacks(file_name)
-}
-
-func BuildPawnMoves() {
-	file_name := "data/white_pawn_move.csv"
-	WHITE_PAWN_MOVES = LoadAttacks(file_name)
-	file_name = "data/black_pawn_move.csv"
-	BLACK_PAWN_MOVES = LoadAttacks(file_name)
-}
-
-func BuildPawnAttacks() {
-	file_name := "data/white_pawn_attacks.csv"
-	WHITE_PAWN_ATTACKS = LoadAttacks(file_name)
-	file_name = "data/black_pawn_attacks.csv"
-	BLACK_PAWN_ATTACKS = LoadAttacks(file_name)
-
+func init() {
+	buildMagicTables(&rookMagics, ROOK_RAY, &rookMagic, &rookAttacks)
+	buildMagicTables(&bishopMagics, BISHOP_RAY, &bishopMagic, &bishopAttacks)
 }
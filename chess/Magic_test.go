@@ -3,41 +3,24 @@ package chess
 import (
 	"encoding/json"
 	"fmt"
+	"math/rand/v2"
 	"os"
 	"testing"
 )
 
 func TestPawnAttacks(t *testing.T) {
-	BuildPawnAttacks()
-
-	if WHITE_PAWN_ATTACKS == nil {
-		t.Error("nil map")
+	e4, _ := ShiftFromAlg("e4")
+	expected, _ := SquaresToBitBoard([]string{"d5", "f5"})
+	if got := PawnAttacks(WHITE, e4); got != expected {
+		t.Errorf("white attack did not match\nexpected:\n%s\ngot:\n%s", expected.String(), got.String())
 	}
-
-	if len(WHITE_PAWN_ATTACKS) != 64 {
-		t.Errorf("map incorrect size, 64 != %d", len(WHITE_PAWN_ATTACKS))
+	expected, _ = SquaresToBitBoard([]string{"d3", "f3"})
+	if got := PawnAttacks(BLACK, e4); got != expected {
+		t.Errorf("black attack did not match\nexpected:\n%s\ngot:\n%s", expected.String(), got.String())
 	}
 }
 
 func TestPawnMoves(t *testing.T) {
-	BuildPawnMoves()
-
-	if WHITE_PAWN_MOVES == nil {
-		t.Error("White nil map")
-	}
-
-	if len(WHITE_PAWN_MOVES) != 64 {
-		t.Errorf("White map incorrect size, 64 != %d", len(WHITE_PAWN_ATTACKS))
-	}
-
-	if BLACK_PAWN_MOVES == nil {
-		t.Error("Black nil map")
-	}
-
-	if len(BLACK_PAWN_MOVES) != 64 {
-		t.Errorf("Black map incorrect size, 64 != %d", len(WHITE_PAWN_ATTACKS))
-	}
-
 	records, err := readCSV("data/white_pawn_move.csv")
 	if err != nil {
 		t.Fatalf("could not open csv %v", err)
@@ -48,8 +31,8 @@ func TestPawnMoves(t *testing.T) {
 		if err != nil {
 			t.Fatalf("could not convert record %v", err)
 		}
-		if WHITE_PAWN_MOVES[val[0]] != BitBoard(val[2]) {
-			t.Errorf("white move did not match gen=%d actual=%d", WHITE_PAWN_MOVES[val[0]], val[2])
+		if PawnMoves(WHITE, Shift(val[0])) != BitBoard(val[2]) {
+			t.Errorf("white move did not match gen=%d actual=%d", PawnMoves(WHITE, Shift(val[0])), val[2])
 		}
 	}
 
@@ -63,12 +46,67 @@ func TestPawnMoves(t *testing.T) {
 		if err != nil {
 			t.Fatalf("could not convert record %v", err)
 		}
-		if BLACK_PAWN_MOVES[val[0]] != BitBoard(val[2]) {
-			t.Errorf("black move did not match gen=%d actual=%d", BLACK_PAWN_MOVES[val[0]], val[2])
+		if PawnMoves(BLACK, Shift(val[0])) != BitBoard(val[2]) {
+			t.Errorf("black move did not match gen=%d actual=%d", PawnMoves(BLACK, Shift(val[0])), val[2])
+		}
+	}
+}
+
+func TestAttackTablesMatchCSV(t *testing.T) {
+	colour := func(table func(Colour, Shift) BitBoard, colour Colour) func(Shift) BitBoard {
+		return func(loc Shift) BitBoard { return table(colour, loc) }
+	}
+	tables := []struct {
+		file_name string
+		table     func(Shift) BitBoard
+	}{
+		{"data/knight_attacks.csv", KnightAttacks},
+		{"data/king_attacks.csv", KingAttacks},
+		{"data/white_pawn_move.csv", colour(PawnMoves, WHITE)},
+		{"data/black_pawn_move.csv", colour(PawnMoves, BLACK)},
+		{"data/white_pawn_attacks.csv", colour(PawnAttacks, WHITE)},
+		{"data/black_pawn_attacks.csv", colour(PawnAttacks, BLACK)},
+	}
+	for _, test := range tables {
+		expected, err := loadAttacks(test.file_name)
+		if err != nil {
+			t.Fatalf("could not load %s: %v", test.file_name, err)
+		}
+		for shift, attack := range expected {
+			if got := test.table(Shift(shift)); got != attack {
+				t.Errorf("%s mismatch at shift %d\nexpected:\n%s\ngot:\n%s",
+					test.file_name, shift, attack.String(), got.String())
+			}
 		}
 	}
 }
 
+func TestSliderAttacks(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	for loc := range Shift(SHIFT_SIZE) {
+		for range 200 {
+			board := BitBoard(r.Uint64() & r.Uint64())
+			rook := RayCast(loc, board, 0, ROOK_RAY)
+			if got := GetRookAttack(loc, board); got != rook {
+				t.Fatalf("rook attack mismatch at %d\nexpected:\n%s\ngot:\n%s", loc, rook.String(), got.String())
+			}
+			bishop := RayCast(loc, board, 0, BISHOP_RAY)
+			if got := GetBishopAttack(loc, board); got != bishop {
+				t.Fatalf("bishop attack mismatch at %d\nexpected:\n%s\ngot:\n%s", loc, bishop.String(), got.String())
+			}
+			if got := GetQueenAttack(loc, board); got != rook|bishop {
+				t.Fatalf("queen attack mismatch at %d", loc)
+			}
+		}
+	}
+}
+
+func TestLoadAttacksMissingFile(t *testing.T) {
+	if _, err := loadAttacks("data/does_not_exist.csv"); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
 func rayCastReference(start Shift, blockers BitBoard, ray Ray) BitBoard {
 	startRow := int(start) / ROW_COL_SIZE
 	startCol := int(start) % ROW_COL_SIZE
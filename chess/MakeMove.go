@@ -0,0 +1,217 @@
+package chess
+
+import (
+	"fmt"
+)
+
+// Plays a move on the board. Captures, castling, en passant and double pawn
+// pushes are worked out from the board, so a move from NewMoveUCI can be used.
+// Castling is given as the king capturing its own rook, as in Chess960 UCI,
+// or as the king moving two files when the king and rook are on their standard
+// squares. The move is not checked for legality, only that it moves a piece of
+// the player to move.
+func (b *BoardState) MakeMove(m Move) error {
+	m, err := b.resolveMove(m)
+	if err != nil {
+		return err
+	}
+	b.applyMove(m)
+	return nil
+}
+
+// Returns the index into pieces of the piece on loc, -1 if the square is empty.
+func (b *BoardState) pieceIndex(loc BitBoard) int {
+	for i, p := range b.pieces {
+		if p&loc > 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// Returns the move with its flags set from the board.
+func (b *BoardState) resolveMove(m Move) (Move, error) {
+	turn := b.Turn()
+	piece := b.pieceIndex(m.start)
+	if piece == -1 || Colour(piece/BLACK_OFFSET) != turn {
+		return m, fmt.Errorf("invalid move %s, no piece of the player to move on %s", m.String(), AlgFromLoc(m.start))
+	}
+
+	promotion := uint16(0)
+	if m.encoding&PROMOTION_FLAG > 0 {
+		promotion = m.encoding & (PROMOTION_FLAG | 3)
+	}
+
+	kind := Piece(piece % BLACK_OFFSET)
+	if kind == KING && promotion == 0 {
+		if i, ok := b.castleRight(m); ok {
+			rook, _ := b.CastleRook(i)
+			if b.GetPieces(turn, ROOK)&(1<<rook) == 0 {
+				return m, fmt.Errorf("invalid move %s, there is no rook on %s to castle with", m.String(), AlgFromLoc(1<<rook))
+			}
+			flags := KING_CASTLE
+			if i%2 == 1 {
+				flags = QUEEN_CASTLE
+			}
+			return Move{m.start, 1 << castleEnd(m.start.LSB(), rook, i), flags}, nil
+		}
+	}
+
+	if m.end&b.Occupied(turn) > 0 {
+		return m, fmt.Errorf("invalid move %s, %s is occupied by a piece of the same colour", m.String(), AlgFromLoc(m.end))
+	}
+
+	flags := QUIET_MOVE
+	if m.end&b.Occupied(1-turn) > 0 {
+		flags = CAPTURE
+	}
+
+	if kind != PAWN {
+		if promotion != 0 {
+			return m, fmt.Errorf("invalid move %s, only pawns can promote", m.String())
+		}
+		return Move{m.start, m.end, flags}, nil
+	}
+
+	switch {
+	case m.end&backRank(1-turn) > 0:
+		if promotion == 0 {
+			return m, fmt.Errorf("invalid move %s, missing promotion piece", m.String())
+		}
+		flags |= promotion
+	case promotion != 0:
+		return m, fmt.Errorf("invalid move %s, pawn does not reach the last rank", m.String())
+	case m.end == b.enpassant:
+		flags = ENPASSANT_CAPTURE
+	case m.end == m.start<<16 || m.end == m.start>>16:
+		flags = DOUBLE_PAWN_PUSH
+	}
+	return Move{m.start, m.end, flags}, nil
+}
+
+// Returns the castle right, an index into CASTLE_SYM, used by a king move.
+// The bool is false if the move is not a castle. The king moving two files is
+// only a castle when the king and rook are on their standard squares.
+func (b *BoardState) castleRight(m Move) (int, bool) {
+	turn := b.Turn()
+	rank := backRank(turn)
+	if m.start&rank == 0 || m.end&rank == 0 {
+		return 0, false
+	}
+
+	start, end := m.start.LSB(), m.end.LSB()
+	for side := range 2 {
+		i := int(turn)*2 + side
+		rook, ok := b.CastleRook(i)
+		if !ok {
+			continue
+		}
+		kingEnd, _ := castleSquares(i)
+		if end == rook || (isStandardCastle(start, rook) && end == kingEnd) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// Returns the squares the king and rook end on for castle right i.
+func castleSquares(i int) (Shift, Shift) {
+	kingEnd, rookEnd := Shift(6), Shift(5)
+	if i%2 == 1 {
+		kingEnd, rookEnd = 2, 3
+	}
+	if i >= 2 {
+		kingEnd += 56
+		rookEnd += 56
+	}
+	return kingEnd, rookEnd
+}
+
+// Returns true if the king is on the e file and the rook in the corner, as in
+// the standard start position.
+func isStandardCastle(king, rook Shift) bool {
+	rank := king - king%ROW_COL_SIZE
+	return king == rank+4 && (rook == rank+7 || rook == rank)
+}
+
+// Returns the end square a castle is written with. Standard castles are
+// written as the king moving two files, Chess960 castles as the king
+// capturing its own rook.
+func castleEnd(king, rook Shift, i int) Shift {
+	if isStandardCastle(king, rook) {
+		kingEnd, _ := castleSquares(i)
+		return kingEnd
+	}
+	return rook
+}
+
+// Plays a move that has been through resolveMove.
+func (b *BoardState) applyMove(m Move) {
+	turn := b.Turn()
+	offset := int(turn) * BLACK_OFFSET
+	piece := b.pieceIndex(m.start)
+
+	b.enpassant = 0
+	b.halfmove_clock++
+
+	switch flags := m.encoding; {
+	case flags == KING_CASTLE || flags == QUEEN_CASTLE:
+		i := int(turn) * 2
+		if flags == QUEEN_CASTLE {
+			i += 1
+		}
+		kingEnd, rookEnd := castleSquares(i)
+		// in Chess960 the king or rook may already be on its end square, so
+		// both are removed before either is placed.
+		rook, _ := b.CastleRook(i)
+		b.pieces[offset+int(KING)] &^= m.start
+		b.pieces[offset+int(ROOK)] &^= 1 << rook
+		b.pieces[offset+int(KING)] |= 1 << kingEnd
+		b.pieces[offset+int(ROOK)] |= 1 << rookEnd
+
+	default:
+		if flags&CAPTURE_FLAG > 0 {
+			captured := m.end
+			if flags == ENPASSANT_CAPTURE {
+				captured = m.end.South()
+				if turn == BLACK {
+					captured = m.end.North()
+				}
+			}
+			for i := range PiecesIter(1 - turn) {
+				b.pieces[i] &^= captured
+			}
+			b.halfmove_clock = 0
+		}
+
+		b.pieces[piece] ^= m.start | m.end
+		if flags&PROMOTION_FLAG > 0 {
+			b.pieces[piece] &^= m.end
+			b.pieces[offset+int(promotionPieces[flags&3])] |= m.end
+		}
+		if Piece(piece%BLACK_OFFSET) == PAWN {
+			b.halfmove_clock = 0
+		}
+		if flags == DOUBLE_PAWN_PUSH {
+			b.enpassant = m.start.North()
+			if turn == BLACK {
+				b.enpassant = m.start.South()
+			}
+		}
+	}
+
+	// moving the king loses both rights, moving or capturing a rook loses its own.
+	if Piece(piece%BLACK_OFFSET) == KING {
+		b.encoding &^= 3 << (int(turn)*2 + 1)
+	}
+	for i := range CASTLE_SYM {
+		if rook, ok := b.CastleRook(i); ok && (m.start|m.end)&(1<<rook) > 0 {
+			b.encoding &^= 1 << (i + 1)
+		}
+	}
+
+	if turn == BLACK {
+		b.fullmove_number++
+	}
+	b.encoding ^= TURN_MASK
+}
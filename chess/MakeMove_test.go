@@ -0,0 +1,85 @@
+package chess
+
+import (
+	"testing"
+)
+
+func TestMakeMove(t *testing.T) {
+	tests := []struct {
+		name  string
+		fen   string
+		moves []string
+		after string
+	}{
+		{"double push", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+			[]string{"e2e4"}, "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1"},
+		{"opening", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+			[]string{"e2e4", "c7c5", "g1f3"}, "rnbqkbnr/pp1ppppp/8/2p5/4P3/5N2/PPPP1PPP/RNBQKB1R b KQkq - 1 2"},
+		{"en passant", "rnbqkbnr/ppp1pppp/8/3pP3/8/8/PPPP1PPP/RNBQKBNR b KQkq - 0 2",
+			[]string{"f7f5", "e5f6"}, "rnbqkbnr/ppp1p1pp/5P2/3p4/8/8/PPPP1PPP/RNBQKBNR b KQkq - 0 3"},
+		{"castle", "r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1",
+			[]string{"e1g1", "e8c8"}, "2kr3r/8/8/8/8/8/8/R4RK1 w - - 2 2"},
+		{"castle onto rook", "r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1",
+			[]string{"e1a1", "e8h8"}, "r4rk1/8/8/8/8/8/8/2KR3R w - - 2 2"},
+		{"rook moves", "r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1",
+			[]string{"h1h8"}, "r3k2R/8/8/8/8/8/8/R3K3 b Qq - 0 1"},
+		{"promotion", "8/1P4k1/8/8/8/8/6K1/8 w - - 0 1",
+			[]string{"b7b8n"}, "1N6/6k1/8/8/8/8/6K1/8 b - - 0 1"},
+		{"promotion capture", "r1q3k1/1P6/8/8/8/8/6K1/8 w - - 0 1",
+			[]string{"b7a8q"}, "Q1q3k1/8/8/8/8/8/6K1/8 b - - 0 1"},
+		{"king moves two files", "4k3/8/8/8/8/8/8/2K4R w H - 0 1",
+			[]string{"c1e1"}, "4k3/8/8/8/8/8/8/4K2R b - - 1 1"},
+		{"king moves two files queen side", "4k3/8/8/8/8/8/8/R2K4 w Q - 0 1",
+			[]string{"d1b1"}, "4k3/8/8/8/8/8/8/RK6 b - - 1 1"},
+		{"chess960 castle", "1r4kr/8/8/8/8/8/8/1R4KR w BHbh - 0 1",
+			[]string{"g1b1", "g8h8"}, "1r3rk1/8/8/8/8/8/8/2KR3R w - - 2 2"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, err := NewBoardFEN(test.fen)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, uci := range test.moves {
+				m, err := NewMoveUCI(uci)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := b.MakeMove(*m); err != nil {
+					t.Fatalf("could not play %s: %v", uci, err)
+				}
+			}
+			if b.FEN() != test.after {
+				t.Errorf("expected %s, got %s", test.after, b.FEN())
+			}
+		})
+	}
+}
+
+func TestMakeMoveInvalid(t *testing.T) {
+	tests := []struct {
+		fen string
+		uci string
+	}{
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "e7e5"},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "e3e4"},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "d1e1"},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "g1f3q"},
+		{"8/1P4k1/8/8/8/8/6K1/8 w - - 0 1", "b7b8"},
+		{"4k3/8/8/8/8/8/8/4K3 w K - 0 1", "e1g1"},
+	}
+	for _, test := range tests {
+		b, _ := NewBoardFEN(test.fen)
+		before := *b
+		m, err := NewMoveUCI(test.uci)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := b.MakeMove(*m); err == nil {
+			t.Errorf("expected error for %s in %s", test.uci, test.fen)
+		}
+		if *b != before {
+			t.Errorf("board changed by invalid move %s", test.uci)
+		}
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"errors"
 	"fmt"
 	"iter"
-	"log"
 	"slices"
 )
 
@@ -14,11 +13,11 @@ type Move struct {
 	encoding uint16   //encoding for move information
 }
 
-// Genrates a new move with no code, TODO: handel codes in the right way.
-// Idea for codes might be somthing that engine will generate after ponder.
+// Genrates a new move from UCI notation. A fifth character sets the promotion
+// flag, the other flags (captures, castling) need a board to be known.
 func NewMoveUCI(UCI string) (*Move, error) {
 	s := fmt.Sprintf("Invalid UCI code %s", UCI)
-	if len(UCI) != 4 {
+	if len(UCI) != 4 && len(UCI) != 5 {
 		return nil, errors.New(s)
 	}
 
@@ -27,58 +26,44 @@ func NewMoveUCI(UCI string) (*Move, error) {
 		return nil, errors.Join(errors.New(s), err)
 	}
 
-	end, err := LocFromAlg(UCI[2:])
+	end, err := LocFromAlg(UCI[2:4])
 	if err != nil {
 		return nil, errors.Join(errors.New(s), err)
 	}
 
-	return &Move{start, end, 0}, nil
+	var encoding uint16 = 0
+	if len(UCI) == 5 {
+		encoding, err = promotionFromUCI(UCI[4])
+		if err != nil {
+			return nil, errors.Join(errors.New(s), err)
+		}
+	}
+
+	return &Move{start, end, encoding}, nil
 }
 
 func (m *Move) String() string {
-	scol, srow, ecol, erow := 0, 0, 0, 0
-	colMask := COLUMN_MASK
-	rowMask := ROW_MASK
-	for i := range 8 {
-		if (colMask & m.start) > 0 {
-			scol = i
-		}
-		if (rowMask & m.start) > 0 {
-			srow = i
-		}
-		if (colMask & m.end) > 0 {
-			ecol = i
-		}
-		if (rowMask & m.end) > 0 {
-			erow = i
-		}
-		colMask = colMask << 1
-		rowMask = rowMask << 8
+	uci := AlgFromLoc(m.start) + AlgFromLoc(m.end)
+	if m.encoding&PROMOTION_FLAG > 0 {
+		uci += string(promotionSym[m.encoding&3])
 	}
-
-	return fmt.Sprintf("%c%d%c%d", COLUMNS[scol], srow+1, COLUMNS[ecol], erow+1)
+	return uci
 }
 
-// find algebraic position from position
+// find algebraic position from position, "-" for an empty board
 func AlgFromLoc(loc BitBoard) string {
-	col, row := 0, 0
-	colMask := COLUMN_MASK
-	rowMask := ROW_MASK
-	for i := range 8 {
-		if colMask&loc > 0 {
-			col = i
-		}
-		if rowMask&loc > 0 {
-			row = i
-		}
-		colMask = colMask << 1
-		rowMask = rowMask << 8
+	if loc == 0 {
+		return "-"
 	}
-
-	return fmt.Sprintf("%c%d", COLUMNS[col], row+1)
+	coord := CoordsFromShift(loc.LSB())
+	return fmt.Sprintf("%c%d", COLUMNS[coord.col], coord.row+1)
 }
 
 func RowColFromAlg(alg string) (uint64, uint64, error) {
+	if len(alg) != 2 {
+		s := fmt.Sprintf("Invalid algerbraic notation %s", alg)
+		return 0, 0, errors.New(s)
+	}
 	col := slices.Index(COLUMNS, rune(alg[0]))
 	if col == -1 {
 		s := fmt.Sprintf("Invalid algerbraic notation %s", alg)
@@ -110,14 +95,16 @@ func LocFromAlg(alg string) (BitBoard, error) {
 	return 1 << shift, nil
 }
 
-func ShiftIter(start_str, stop_str string) iter.Seq[Shift] {
+// Iterates over the shifts from start_str to stop_str, both given in
+// algebraic notation.
+func ShiftIter(start_str, stop_str string) (iter.Seq[Shift], error) {
 	start, err := ShiftFromAlg(start_str)
 	if err != nil {
-		log.Fatal(err.Error())
+		return nil, err
 	}
 	stop, err := ShiftFromAlg(stop_str)
 	if err != nil {
-		log.Fatal(err.Error())
+		return nil, err
 	}
 	return func(yield func(Shift) bool) {
 		for i := start; i <= stop; i++ {
@@ -125,5 +112,5 @@ func ShiftIter(start_str, stop_str string) iter.Seq[Shift] {
 				return
 			}
 		}
-	}
+	}, nil
 }
@@ -0,0 +1,295 @@
+package chess
+
+// Squares between two squares on a rank, file or diagonal, and the whole line
+// through them. Both are empty when the squares are not aligned. Built once
+// when the package is loaded.
+var (
+	betweenTable [SHIFT_SIZE][SHIFT_SIZE]BitBoard
+	lineTable    [SHIFT_SIZE][SHIFT_SIZE]BitBoard
+)
+
+// Returns the squares strictly between a and b, empty if they are not on the
+// same rank, file or diagonal.
+func Between(a, b Shift) BitBoard {
+	return betweenTable[a][b]
+}
+
+// Returns every square of the rank, file or diagonal through a and b, empty if
+// they are not aligned.
+func Line(a, b Shift) BitBoard {
+	return lineTable[a][b]
+}
+
+// Adds every legal move of the position to list. Checkers, the squares that
+// block or capture a checker and the pinned pieces are found once, then every
+// piece's targets are masked by them, so no move has to be played to test it.
+func (b *BoardState) GenerateLegal(list *MoveList) {
+	b.generate(list, true)
+}
+
+// Adds every pseudo-legal move to list, these may leave the king in check.
+// Castling is always checked in full, since it can not be tested by playing
+// the move and looking at the king.
+func (b *BoardState) GeneratePseudoLegal(list *MoveList) {
+	b.generate(list, false)
+}
+
+func (b *BoardState) generate(list *MoveList, legal bool) {
+	us := b.Turn()
+	them := 1 - us
+	own := b.Occupied(us)
+	enemy := b.Occupied(them)
+	occupied := own | enemy
+
+	kingBoard := b.GetPieces(us, KING)
+	king := kingBoard.LSB()
+	legal = legal && kingBoard != 0
+
+	// moves other than the king's must land on checkMask, pinned pieces must
+	// stay on the line through the king and the pinner.
+	checkMask := ^EMPTY_BOARD
+	var pinned BitBoard
+	if legal {
+		checkers := b.Attackers(king, them, occupied)
+		switch checkers.PopCount() {
+		case 0:
+		case 1:
+			checkMask = checkers | Between(king, checkers.LSB())
+		default:
+			checkMask = 0
+		}
+		pinned = b.pinned(king, us, occupied)
+	}
+
+	if kingBoard != 0 {
+		targets := KingAttacks(king) &^ own
+		for targets != 0 {
+			to := targets.PopLSB()
+			// the king is removed so it can not hide behind itself from a slider.
+			if legal && b.Attackers(to, them, occupied^kingBoard) != 0 {
+				continue
+			}
+			list.Add(NewPackedMove(king, to, captureFlag(to, enemy)))
+		}
+		b.generateCastles(list, king, occupied)
+	}
+
+	// in double check only the king can move.
+	if checkMask == 0 {
+		return
+	}
+
+	targets := ^own & checkMask
+	for _, piece := range []Piece{KNIGHT, BISHOP, ROOK, QUEEN} {
+		pieces := b.GetPieces(us, piece)
+		for pieces != 0 {
+			from := pieces.PopLSB()
+			var moves BitBoard
+			switch piece {
+			case KNIGHT:
+				moves = KnightAttacks(from)
+			case BISHOP:
+				moves = GetBishopAttack(from, occupied)
+			case ROOK:
+				moves = GetRookAttack(from, occupied)
+			case QUEEN:
+				moves = GetQueenAttack(from, occupied)
+			}
+			moves &= targets
+			if pinned&(1<<from) > 0 {
+				moves &= Line(king, from)
+			}
+			for moves != 0 {
+				to := moves.PopLSB()
+				list.Add(NewPackedMove(from, to, captureFlag(to, enemy)))
+			}
+		}
+	}
+
+	b.generatePawns(list, legal, king, checkMask, pinned)
+}
+
+func (b *BoardState) generatePawns(list *MoveList, legal bool, king Shift, checkMask, pinned BitBoard) {
+	us := b.Turn()
+	enemy := b.Occupied(1 - us)
+	occupied := b.Occupied(BOTH)
+
+	startRank, lastRank := ROW_MASK<<8, RANK_8
+	if us == BLACK {
+		startRank, lastRank = ROW_MASK<<48, RANK_1
+	}
+	forward := func(board BitBoard) BitBoard {
+		if us == WHITE {
+			return board.North()
+		}
+		return board.South()
+	}
+
+	pawns := b.GetPieces(us, PAWN)
+	for pawns != 0 {
+		from := pawns.PopLSB()
+		fromBoard := BitBoard(1) << from
+
+		mask := checkMask
+		if pinned&fromBoard > 0 {
+			mask &= Line(king, from)
+		}
+
+		single := forward(fromBoard) &^ occupied
+		var double BitBoard
+		if fromBoard&startRank > 0 {
+			double = forward(single) &^ occupied
+		}
+		attacks := PawnAttacks(us, from)
+
+		moves := (single | attacks&enemy) & mask
+		for moves != 0 {
+			to := moves.PopLSB()
+			flags := captureFlag(to, enemy)
+			if BitBoard(1)<<to&lastRank == 0 {
+				list.Add(NewPackedMove(from, to, flags))
+				continue
+			}
+			for promotion := range uint16(len(promotionPieces)) {
+				list.Add(NewPackedMove(from, to, flags|PROMOTION_FLAG|promotion))
+			}
+		}
+		if double&mask > 0 {
+			list.Add(NewPackedMove(from, double.LSB(), DOUBLE_PAWN_PUSH))
+		}
+		if attacks&b.enpassant > 0 && (!legal || b.enpassantLegal(fromBoard, king)) {
+			list.Add(NewPackedMove(from, b.enpassant.LSB(), ENPASSANT_CAPTURE))
+		}
+	}
+}
+
+// Returns true if an en passant capture by the pawn on from does not leave the
+// king in check. Both pawns leave the rank of the king at once, so the capture
+// is tested by removing them instead of with the pin and check masks.
+func (b *BoardState) enpassantLegal(from BitBoard, king Shift) bool {
+	us := b.Turn()
+	captured := b.enpassant.South()
+	if us == BLACK {
+		captured = b.enpassant.North()
+	}
+	occupied := b.Occupied(BOTH) ^ from ^ captured | b.enpassant
+	return b.Attackers(king, 1-us, occupied)&^captured == 0
+}
+
+// Adds the castles of the player to move. The squares the king and rook move
+// through must be empty apart from the two of them, and the king may not be in
+// check or pass through an attacked square.
+func (b *BoardState) generateCastles(list *MoveList, king Shift, occupied BitBoard) {
+	us := b.Turn()
+	// the rights only belong to a king on its back rank, where the rooks are.
+	if backRank(us)&(1<<king) == 0 {
+		return
+	}
+	for side := range 2 {
+		i := int(us)*2 + side
+		rook, ok := b.CastleRook(i)
+		if !ok || b.GetPieces(us, ROOK)&(1<<rook) == 0 {
+			continue
+		}
+		kingEnd, rookEnd := castleSquares(i)
+		flags := KING_CASTLE
+		if side == 1 {
+			flags = QUEEN_CASTLE
+		}
+
+		rest := occupied &^ (1<<king | 1<<rook)
+		path := Between(king, kingEnd) | Between(rook, rookEnd) | 1<<kingEnd | 1<<rookEnd
+		if path&rest > 0 {
+			continue
+		}
+		safe := true
+		squares := Between(king, kingEnd) | 1<<king | 1<<kingEnd
+		for squares != 0 {
+			if b.Attackers(squares.PopLSB(), 1-us, rest) != 0 {
+				safe = false
+				break
+			}
+		}
+		if !safe {
+			continue
+		}
+		list.Add(NewPackedMove(king, castleEnd(king, rook, i), flags))
+	}
+}
+
+// Returns the pieces of colour us that are pinned to the king on king.
+func (b *BoardState) pinned(king Shift, us Colour, occupied BitBoard) BitBoard {
+	them := 1 - us
+	enemy := b.Occupied(them)
+	queens := b.GetPieces(them, QUEEN)
+	snipers := GetRookAttack(king, enemy)&(b.GetPieces(them, ROOK)|queens) |
+		GetBishopAttack(king, enemy)&(b.GetPieces(them, BISHOP)|queens)
+
+	var pinned BitBoard
+	for snipers != 0 {
+		blockers := Between(king, snipers.PopLSB()) & occupied
+		if blockers.PopCount() == 1 {
+			pinned |= blockers & b.Occupied(us)
+		}
+	}
+	return pinned
+}
+
+func captureFlag(to Shift, enemy BitBoard) uint16 {
+	if enemy&(1<<to) > 0 {
+		return CAPTURE
+	}
+	return QUIET_MOVE
+}
+
+// Plays a move from the move generator, the flags of the move are trusted.
+func (b *BoardState) PlayMove(m PackedMove) {
+	b.applyMove(m.Unpack())
+}
+
+// Counts the leaf nodes of the legal move tree to depth, used to check the move
+// generator against known numbers.
+func Perft(b *BoardState, depth int) uint64 {
+	if depth == 0 {
+		return 1
+	}
+	var list MoveList
+	b.GenerateLegal(&list)
+	if depth == 1 {
+		return uint64(list.Len())
+	}
+
+	var nodes uint64
+	for _, m := range list.Moves() {
+		next := *b
+		next.PlayMove(m)
+		nodes += Perft(&next, depth-1)
+	}
+	return nodes
+}
+
+func init() {
+	directions := append(ROOK_RAY[:], BISHOP_RAY[:]...)
+	for a := range Shift(SHIFT_SIZE) {
+		for _, direction := range directions {
+			line := RayCast(a, 0, 0, Ray{direction}) |
+				RayCast(a, 0, 0, Ray{{-direction[0], -direction[1]}}) | 1<<a
+
+			var between BitBoard
+			ray := RayCast(a, 0, 0, Ray{direction})
+			for ray != 0 {
+				// the ray is walked outwards from a.
+				var to Shift
+				if direction[0] > 0 || (direction[0] == 0 && direction[1] > 0) {
+					to = ray.PopLSB()
+				} else {
+					to = ray.MSB()
+					ray &^= 1 << to
+				}
+				betweenTable[a][to] = between
+				lineTable[a][to] = line
+				between |= 1 << to
+			}
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package chess
+
+import (
+	"testing"
+)
+
+// known perft numbers, from the Chess Programming Wiki and the Chess960 perft
+// suite.
+var perftTests = []struct {
+	fen   string
+	nodes []uint64 //nodes at depth 1, 2, ...
+}{
+	{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", []uint64{20, 400, 8902, 197281}},
+	{"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1", []uint64{48, 2039, 97862}},
+	{"8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1", []uint64{14, 191, 2812, 43238, 674624}},
+	{"r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1", []uint64{6, 264, 9467, 422333}},
+	{"rnbq1k1r/pp1Pbppp/2p5/8/2B5/8/PPP1NnPP/RNBQK2R w KQ - 1 8", []uint64{44, 1486, 62379}},
+	{"r4rk1/1pp1qppp/p1np1n2/2b1p1B1/2B1P1b1/P1NP1N2/1PP1QPPP/R4RK1 w - - 0 10", []uint64{46, 2079, 89890}},
+	{"bqnb1rkr/pp3ppp/3ppn2/2p5/5P2/P2P4/NPP1P1PP/BQ1BNRKR w HFhf - 2 9", []uint64{21, 528, 12189, 326672}},
+}
+
+func TestPerft(t *testing.T) {
+	for _, test := range perftTests {
+		b, err := NewBoardFEN(test.fen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, expected := range test.nodes {
+			depth := i + 1
+			if testing.Short() && expected > 100000 {
+				break
+			}
+			if got := Perft(b, depth); got != expected {
+				t.Errorf("%s depth %d, expected %d got %d", test.fen, depth, expected, got)
+			}
+		}
+	}
+}
+
+// counts the leaf nodes using pseudo-legal moves, dropping the ones that leave
+// the king in check.
+func perftFiltered(b *BoardState, depth int) uint64 {
+	if depth == 0 {
+		return 1
+	}
+	var list MoveList
+	b.GeneratePseudoLegal(&list)
+
+	var nodes uint64
+	for _, m := range list.Moves() {
+		next := *b
+		next.PlayMove(m)
+		king := next.GetPieces(b.Turn(), KING)
+		if next.IsAttacked(king.LSB(), next.Turn()) {
+			continue
+		}
+		nodes += perftFiltered(&next, depth-1)
+	}
+	return nodes
+}
+
+func TestPerftMatchesFiltered(t *testing.T) {
+	fens := []string{
+		"r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1",
+		"8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1",
+		"r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1",
+		// en passant that would expose the king along the rank.
+		"8/8/8/K1pP3q/8/8/8/7k w - c6 0 1",
+		"8/8/8/8/k2Pp2Q/8/8/3K4 b - d3 0 1",
+		// en passant out of check by the pawn that just moved.
+		"8/8/8/2k5/3Pp3/8/8/4K3 b - d3 0 1",
+		"2nnrbkr/p1qppppp/8/1ppb4/6PP/3PP3/PPP2P2/BQNNRBKR w HEhe - 1 9",
+		"1r4kr/8/8/8/8/8/8/1R4KR w BHbh - 0 1",
+		// castle right with the king off its back rank.
+		"4k3/8/8/8/8/8/4K3/7R w K - 0 1",
+	}
+	for _, fen := range fens {
+		b, err := NewBoardFEN(fen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for depth := 1; depth <= 3; depth++ {
+			legal, filtered := Perft(b, depth), perftFiltered(b, depth)
+			if legal != filtered {
+				t.Errorf("%s depth %d, legal %d filtered %d", fen, depth, legal, filtered)
+			}
+		}
+	}
+}
+
+func TestLegalMoves(t *testing.T) {
+	b, _ := NewBoardFEN("8/8/8/K1pP3q/8/8/8/7k w - c6 0 1")
+	for _, m := range b.LegalMoves() {
+		if m.String() == "d5c6" {
+			t.Error("en passant exposing the king should not be legal")
+		}
+	}
+
+	b, _ = NewBoardFEN("4k3/8/8/8/8/8/4K3/7R w K - 0 1")
+	for _, m := range b.LegalMoves() {
+		if m.String() == "e2h1" {
+			t.Error("king off its back rank should not castle")
+		}
+	}
+
+	b, _ = NewBoardFEN("r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1")
+	castles := 0
+	for _, m := range b.LegalMoves() {
+		if m.String() == "e1g1" || m.String() == "e1c1" {
+			castles++
+			if err := b.MakeMove(m); err != nil {
+				t.Errorf("could not play generated move %s: %v", m.String(), err)
+			}
+			b, _ = NewBoardFEN("r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1")
+		}
+	}
+	if castles != 2 {
+		t.Errorf("expected 2 castles, got %d", castles)
+	}
+}
@@ -29,3 +29,34 @@ func TestNewMove(t *testing.T) {
 		}
 	}
 }
+
+func TestAlgFromLocEmpty(t *testing.T) {
+	if got := AlgFromLoc(0); got != "-" {
+		t.Errorf("expected - for an empty board, got %s", got)
+	}
+	m := Move{}
+	if got := m.String(); got != "--" {
+		t.Errorf("expected -- for an empty move, got %s", got)
+	}
+}
+
+func TestShiftIter(t *testing.T) {
+	shifts, err := ShiftIter("a2", "h2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	count := 0
+	for shift := range shifts {
+		if shift != Shift(8+count) {
+			t.Errorf("expected shift %d, got %d", 8+count, shift)
+		}
+		count++
+	}
+	if count != 8 {
+		t.Errorf("expected 8 shifts, got %d", count)
+	}
+
+	if _, err := ShiftIter("a2", "z9"); err == nil {
+		t.Error("expected error for an invalid square")
+	}
+}
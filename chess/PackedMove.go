@@ -0,0 +1,155 @@
+package chess
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// PackedMove is a move packed into 16 bits, 6 bits for the start shift,
+// 6 bits for the end shift and 4 bits of flags.
+type PackedMove uint16
+
+const NULL_MOVE PackedMove = 0
+
+// Move flags, used by PackedMove and the encoding of Move.
+const (
+	QUIET_MOVE           uint16 = 0
+	DOUBLE_PAWN_PUSH     uint16 = 1
+	KING_CASTLE          uint16 = 2
+	QUEEN_CASTLE         uint16 = 3
+	CAPTURE              uint16 = 4
+	ENPASSANT_CAPTURE    uint16 = 5
+	KNIGHT_PROMOTION     uint16 = 8
+	BISHOP_PROMOTION     uint16 = 9
+	ROOK_PROMOTION       uint16 = 10
+	QUEEN_PROMOTION      uint16 = 11
+	KNIGHT_PROMO_CAPTURE uint16 = 12
+	BISHOP_PROMO_CAPTURE uint16 = 13
+	ROOK_PROMO_CAPTURE   uint16 = 14
+	QUEEN_PROMO_CAPTURE  uint16 = 15
+
+	CAPTURE_FLAG   uint16 = 4
+	PROMOTION_FLAG uint16 = 8
+	FLAG_MASK      uint16 = 15
+)
+
+// promotion pieces in the order of the promotion flags.
+var (
+	promotionPieces = [4]Piece{KNIGHT, BISHOP, ROOK, QUEEN}
+	promotionSym    = [4]rune{'n', 'b', 'r', 'q'}
+)
+
+func NewPackedMove(start, end Shift, flags uint16) PackedMove {
+	return PackedMove(uint16(start&63) | uint16(end&63)<<6 | (flags&FLAG_MASK)<<12)
+}
+
+// Parses a move from UCI notation, e.g. e2e4 or e7e8q. Only the promotion
+// flag can be known from the notation, captures and castling need a board.
+func NewPackedMoveUCI(UCI string) (PackedMove, error) {
+	m, err := NewMoveUCI(UCI)
+	if err != nil {
+		return NULL_MOVE, err
+	}
+	return m.Pack(), nil
+}
+
+func (m PackedMove) Start() Shift {
+	return Shift(m & 63)
+}
+
+func (m PackedMove) End() Shift {
+	return Shift((m >> 6) & 63)
+}
+
+func (m PackedMove) Flags() uint16 {
+	return uint16(m>>12) & FLAG_MASK
+}
+
+func (m PackedMove) IsCapture() bool {
+	return m.Flags()&CAPTURE_FLAG > 0
+}
+
+func (m PackedMove) IsPromotion() bool {
+	return m.Flags()&PROMOTION_FLAG > 0
+}
+
+// Returns the piece a pawn is promoted to, ALL if the move is not a promotion.
+func (m PackedMove) Promotion() Piece {
+	if !m.IsPromotion() {
+		return ALL
+	}
+	return promotionPieces[m.Flags()&3]
+}
+
+// Unpacks into a Move.
+func (m PackedMove) Unpack() Move {
+	return Move{BitBoard(1) << m.Start(), BitBoard(1) << m.End(), m.Flags()}
+}
+
+// Returns the move in UCI notation.
+func (m PackedMove) String() string {
+	uci := AlgFromLoc(1<<m.Start()) + AlgFromLoc(1<<m.End())
+	if m.IsPromotion() {
+		uci += string(promotionSym[m.Flags()&3])
+	}
+	return uci
+}
+
+// Packs a move into 16 bits, a move without a start or end is NULL_MOVE.
+func (m *Move) Pack() PackedMove {
+	if m.start == 0 || m.end == 0 {
+		return NULL_MOVE
+	}
+	return NewPackedMove(m.start.LSB(), m.end.LSB(), m.encoding)
+}
+
+// Returns the promotion flag for a UCI promotion character.
+func promotionFromUCI(c byte) (uint16, error) {
+	idx := slices.Index(promotionSym[:], rune(c))
+	if idx == -1 {
+		return 0, fmt.Errorf("Invalid promotion piece %c", c)
+	}
+	return PROMOTION_FLAG | uint16(idx), nil
+}
+
+// MAX_MOVES is more than the number of legal moves in any chess position.
+const MAX_MOVES = 256
+
+// MoveList is a fixed size list of moves so move generation does not allocate.
+type MoveList struct {
+	moves [MAX_MOVES]PackedMove
+	count int
+}
+
+func (l *MoveList) Add(m PackedMove) {
+	l.moves[l.count] = m
+	l.count++
+}
+
+func (l *MoveList) Len() int {
+	return l.count
+}
+
+func (l *MoveList) Get(i int) PackedMove {
+	return l.moves[i]
+}
+
+func (l *MoveList) Clear() {
+	l.count = 0
+}
+
+// Returns the moves in the list, the slice shares memory with the list.
+func (l *MoveList) Moves() []PackedMove {
+	return l.moves[:l.count]
+}
+
+func (l *MoveList) All() iter.Seq[PackedMove] {
+	return func(yield func(PackedMove) bool) {
+		for _, m := range l.moves[:l.count] {
+			if !yield(m) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package chess
+
+import (
+	"testing"
+)
+
+func TestPackedMove(t *testing.T) {
+	tests := []struct {
+		uci       string
+		flags     uint16
+		promotion Piece
+	}{
+		{"e2e4", QUIET_MOVE, ALL},
+		{"a1h8", QUIET_MOVE, ALL},
+		{"h8a1", QUIET_MOVE, ALL},
+		{"e7e8q", QUEEN_PROMOTION, QUEEN},
+		{"b2b1n", KNIGHT_PROMOTION, KNIGHT},
+		{"g7g8r", ROOK_PROMOTION, ROOK},
+		{"c7c8b", BISHOP_PROMOTION, BISHOP},
+	}
+	for _, test := range tests {
+		m, err := NewPackedMoveUCI(test.uci)
+		if err != nil {
+			t.Fatalf("could not parse %s: %v", test.uci, err)
+		}
+		if m.String() != test.uci {
+			t.Errorf("expected %s, got %s", test.uci, m.String())
+		}
+		if m.Flags() != test.flags {
+			t.Errorf("%s: expected flags %d, got %d", test.uci, test.flags, m.Flags())
+		}
+		if m.Promotion() != test.promotion {
+			t.Errorf("%s: expected promotion %d, got %d", test.uci, test.promotion, m.Promotion())
+		}
+
+		unpacked := m.Unpack()
+		if unpacked.String() != test.uci {
+			t.Errorf("unpacked move expected %s, got %s", test.uci, unpacked.String())
+		}
+		if unpacked.Pack() != m {
+			t.Errorf("%s did not survive an unpack and pack", test.uci)
+		}
+	}
+
+	for _, uci := range []string{"e7e8k", "e7e8x", "e7e8Q", "e7e8kx", "e2x", "e2", "", "e7e9q", "i7e8q"} {
+		if _, err := NewPackedMoveUCI(uci); err == nil {
+			t.Errorf("expected error for %q", uci)
+		}
+	}
+}
+
+func TestPackedMoveFields(t *testing.T) {
+	for start := Shift(0); start < SHIFT_SIZE; start++ {
+		for end := Shift(0); end < SHIFT_SIZE; end++ {
+			for flags := range FLAG_MASK + 1 {
+				m := NewPackedMove(start, end, flags)
+				if m.Start() != start || m.End() != end || m.Flags() != flags {
+					t.Fatalf("packed move %d did not round trip %d %d %d", m, start, end, flags)
+				}
+				if m.IsCapture() != (flags&CAPTURE_FLAG > 0) {
+					t.Fatalf("capture flag wrong for flags %d", flags)
+				}
+			}
+		}
+	}
+}
+
+func TestMoveList(t *testing.T) {
+	var list MoveList
+	moves := []string{"e2e4", "d2d4", "g1f3"}
+	for _, uci := range moves {
+		m, _ := NewPackedMoveUCI(uci)
+		list.Add(m)
+	}
+	if list.Len() != len(moves) {
+		t.Fatalf("expected %d moves, got %d", len(moves), list.Len())
+	}
+	i := 0
+	for m := range list.All() {
+		if m.String() != moves[i] || list.Get(i) != m || list.Moves()[i] != m {
+			t.Errorf("move %d expected %s, got %s", i, moves[i], m.String())
+		}
+		i++
+	}
+	list.Clear()
+	if list.Len() != 0 || len(list.Moves()) != 0 {
+		t.Error("expected empty list after clear")
+	}
+}
+
+func TestPackedMoveOutOfRange(t *testing.T) {
+	if m := NewPackedMove(64, 0, 0); m.Start() != 0 || m.End() != 0 {
+		t.Errorf("shift 64 spilled into the end square, got %s", m.String())
+	}
+	if m := NewPackedMove(0, 64, QUIET_MOVE); m.Flags() != QUIET_MOVE {
+		t.Errorf("shift 64 spilled into the flags, got %d", m.Flags())
+	}
+	empty := Move{}
+	if empty.Pack() != NULL_MOVE {
+		t.Errorf("expected an empty move to pack to NULL_MOVE, got %d", empty.Pack())
+	}
+}
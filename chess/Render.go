@@ -0,0 +1,131 @@
+package chess
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// ANSI escape codes used for coloured output.
+const (
+	ANSI_RESET       = "\x1b[0m"
+	ANSI_LIGHT       = "\x1b[48;5;180m"
+	ANSI_DARK        = "\x1b[48;5;137m"
+	ANSI_LAST_MOVE   = "\x1b[48;5;143m"
+	ANSI_HIGHLIGHT   = "\x1b[48;5;74m"
+	ANSI_CHECK       = "\x1b[48;5;160m"
+	ANSI_WHITE_PIECE = "\x1b[1;97m"
+	ANSI_BLACK_PIECE = "\x1b[1;30m"
+)
+
+// Options for Render, the zero value is a plain letter board. String uses the
+// zero value with Info set.
+type RenderOptions struct {
+	Unicode     bool     // use Unicode piece symbols instead of letters
+	Labels      bool     // show rank and file labels
+	Perspective Colour   // colour at the bottom of the board, BOTH is treated as WHITE
+	Colour      bool     // use ANSI colours for the squares and pieces
+	LastMove    *Move    // squares of the last move to highlight
+	Highlight   BitBoard // extra squares to highlight
+	Check       bool     // highlight the king of the player to move if it is in check
+	Info        bool     // show the turn, castle, enpassant and move number info
+}
+
+// Writes the board to w as text.
+func (b *BoardState) Render(w io.Writer, opts RenderOptions) error {
+	out := bufio.NewWriter(w)
+
+	symbols := PICECES_SYM
+	if opts.Unicode {
+		symbols = UNI_PICECES_SYM
+	}
+
+	// squares with a background other than the board colour, without colour
+	// they are marked with brackets.
+	var last, check BitBoard
+	if opts.LastMove != nil {
+		last = opts.LastMove.start | opts.LastMove.end
+	}
+	if opts.Check && b.InCheck() {
+		check = b.GetPieces(b.Turn(), KING)
+	}
+	marked := last | check | opts.Highlight
+
+	rows := []int{7, 6, 5, 4, 3, 2, 1, 0}
+	cols := []int{0, 1, 2, 3, 4, 5, 6, 7}
+	if opts.Perspective == BLACK {
+		rows = []int{0, 1, 2, 3, 4, 5, 6, 7}
+		cols = []int{7, 6, 5, 4, 3, 2, 1, 0}
+	}
+
+	for _, row := range rows {
+		if opts.Labels {
+			out.WriteRune(rune('1' + row))
+			out.WriteRune(' ')
+		}
+		for _, col := range cols {
+			mask := BitBoard(1) << (uint(row*8 + col))
+			symbol := "_"
+			piece := -1
+			for k, p := range b.pieces {
+				if mask&p > 0 {
+					symbol = symbols[k]
+					piece = k
+					break
+				}
+			}
+
+			if !opts.Colour {
+				if mask&marked > 0 {
+					out.WriteString("[" + symbol + "]")
+				} else {
+					out.WriteString(" " + symbol + " ")
+				}
+				continue
+			}
+
+			switch {
+			case mask&check > 0:
+				out.WriteString(ANSI_CHECK)
+			case mask&opts.Highlight > 0:
+				out.WriteString(ANSI_HIGHLIGHT)
+			case mask&last > 0:
+				out.WriteString(ANSI_LAST_MOVE)
+			case (row+col)%2 == 0:
+				out.WriteString(ANSI_DARK)
+			default:
+				out.WriteString(ANSI_LIGHT)
+			}
+			switch {
+			case piece == -1:
+				symbol = " "
+			case piece < BLACK_OFFSET:
+				out.WriteString(ANSI_WHITE_PIECE)
+			default:
+				out.WriteString(ANSI_BLACK_PIECE)
+			}
+			out.WriteString(" " + symbol + " ")
+			out.WriteString(ANSI_RESET)
+		}
+		out.WriteRune('\n')
+	}
+
+	if opts.Labels {
+		out.WriteString("  ")
+		for _, col := range cols {
+			out.WriteString(" " + string(COLUMNS[col]) + " ")
+		}
+		out.WriteRune('\n')
+	}
+	if opts.Info {
+		out.WriteString(b.InfoString())
+	}
+	return out.Flush()
+}
+
+// Returns the board rendered with opts as a string.
+func (b *BoardState) RenderString(opts RenderOptions) string {
+	var builder strings.Builder
+	b.Render(&builder, opts)
+	return builder.String()
+}
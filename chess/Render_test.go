@@ -0,0 +1,90 @@
+package chess
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRenderPlain(t *testing.T) {
+	b := NewBoardDefault()
+	expected := strings.Join([]string{
+		" r  n  b  q  k  b  n  r ",
+		" p  p  p  p  p  p  p  p ",
+		" _  _  _  _  _  _  _  _ ",
+		" _  _  _  _  _  _  _  _ ",
+		" _  _  _  _  _  _  _  _ ",
+		" _  _  _  _  _  _  _  _ ",
+		" P  P  P  P  P  P  P  P ",
+		" R  N  B  Q  K  B  N  R ",
+		" w KQkq - 0 0",
+	}, "\n")
+	if b.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, b.String())
+	}
+}
+
+func TestRenderOptions(t *testing.T) {
+	b, _ := NewBoardFEN("4k3/8/8/8/8/8/4P3/4K2R w K - 0 1")
+	m, _ := NewMoveUCI("e1g1")
+
+	var buffer bytes.Buffer
+	err := b.Render(&buffer, RenderOptions{Labels: true, Perspective: BLACK, LastMove: m})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(buffer.String(), "\n")
+	if lines[0] != "1  R [_] _ [K] _  _  _  _ " {
+		t.Errorf("unexpected first rank %q", lines[0])
+	}
+	if lines[7] != "8  _  _  _  k  _  _  _  _ " {
+		t.Errorf("unexpected last rank %q", lines[7])
+	}
+	if lines[8] != "   h  g  f  e  d  c  b  a " {
+		t.Errorf("unexpected file labels %q", lines[8])
+	}
+
+	coloured := b.RenderString(RenderOptions{Colour: true})
+	if !strings.Contains(coloured, ANSI_WHITE_PIECE+" K ") || !strings.Contains(coloured, ANSI_RESET) {
+		t.Error("expected ANSI colours in coloured output")
+	}
+}
+
+func TestRenderCheck(t *testing.T) {
+	b, _ := NewBoardFEN("4k3/8/8/8/8/8/8/4K2r w - - 0 1")
+	plain := b.RenderString(RenderOptions{Check: true})
+	if !strings.Contains(plain, "[K]") {
+		t.Errorf("expected king in check to be marked:\n%s", plain)
+	}
+
+	highlight, _ := SquaresToBitBoard([]string{"a8", "e8"})
+	plain = b.RenderString(RenderOptions{Highlight: highlight})
+	if !strings.HasPrefix(plain, "[_] _  _  _ [k]") {
+		t.Errorf("expected highlighted squares to be marked:\n%s", plain)
+	}
+}
+
+func TestAttackers(t *testing.T) {
+	b, _ := NewBoardFEN("3qk3/4P3/8/1B6/8/3n4/8/R3K3 b - - 0 1")
+	e8, _ := ShiftFromAlg("e8")
+	e1, _ := ShiftFromAlg("e1")
+
+	expected, _ := SquaresToBitBoard([]string{"b5"})
+	if got := b.Attackers(e8, WHITE, b.Occupied(BOTH)); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected.String(), got.String())
+	}
+	if !b.InCheck() {
+		t.Error("expected black to be in check")
+	}
+
+	// e7 pawn attacks d8 and f8.
+	d8, _ := ShiftFromAlg("d8")
+	if !b.IsAttacked(d8, WHITE) {
+		t.Error("expected d8 to be attacked by the e7 pawn")
+	}
+
+	expected, _ = SquaresToBitBoard([]string{"d3"})
+	if got := b.Attackers(e1, BLACK, b.Occupied(BOTH)); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected.String(), got.String())
+	}
+}
@@ -0,0 +1,102 @@
+package chess
+
+import (
+	"fmt"
+)
+
+// Score is a search evaluation in centipawns from the side to move's point of view.
+// Scores within MAX_PLY of MATE_SCORE encode a forced mate, the distance to
+// mate is the difference in half moves.
+type Score int32
+
+const MAX_PLY = 128
+
+const (
+	DRAW_SCORE Score = 0
+	MATE_SCORE Score = 32000
+	MATE_BOUND Score = MATE_SCORE - MAX_PLY // any score past this is a mate score
+	INF_SCORE  Score = MATE_SCORE + 1
+)
+
+// Score for the side to move delivering mate in ply half moves.
+func MateIn(ply int) Score {
+	return MATE_SCORE - Score(ply)
+}
+
+// Score for the side to move being mated in ply half moves.
+func MatedIn(ply int) Score {
+	return -MATE_SCORE + Score(ply)
+}
+
+// Returns true if the score encodes a forced mate for either side.
+func (s Score) IsMate() bool {
+	return s >= MATE_BOUND || s <= -MATE_BOUND
+}
+
+// Returns the number of full moves until mate, positive if the side to move
+// is mating and negative if it is being mated. Returns 0 for non mate scores.
+func (s Score) MateMoves() int {
+	switch {
+	case s >= MATE_BOUND:
+		return int(MATE_SCORE-s+1) / 2
+	case s <= -MATE_BOUND:
+		return -int(MATE_SCORE+s) / 2
+	default:
+		return 0
+	}
+}
+
+// Converts a score found at ply from the root into one that is relative to the
+// current node, so it can be stored in the transposition table and reused at a
+// different ply.
+func (s Score) ToTT(ply int) Score {
+	switch {
+	case s >= MATE_BOUND:
+		return s + Score(ply)
+	case s <= -MATE_BOUND:
+		return s - Score(ply)
+	default:
+		return s
+	}
+}
+
+// Converts a score loaded from the transposition table back into a score
+// relative to the root when probed at ply. Inverse of ToTT.
+func ScoreFromTT(s Score, ply int) Score {
+	switch {
+	case s >= MATE_BOUND:
+		return s - Score(ply)
+	case s <= -MATE_BOUND:
+		return s + Score(ply)
+	default:
+		return s
+	}
+}
+
+// Mate distance pruning, narrows the alpha beta window at ply to the best and
+// worst mate scores still possible. If the window closes the node can be cut
+// and the returned bool is true.
+func MateDistancePrune(alpha, beta Score, ply int) (Score, Score, bool) {
+	alpha = max(alpha, MatedIn(ply))
+	beta = min(beta, MateIn(ply+1))
+	return alpha, beta, alpha >= beta
+}
+
+// Returns the search window for proving a mate in at most moves full moves,
+// used by a mate only search. Any score inside the window is a mate that is
+// short enough.
+func MateWindow(moves int) (Score, Score) {
+	return MateIn(2*moves-1) - 1, MATE_SCORE
+}
+
+// Returns the score as used by the UCI info command, "cp <x>" or "mate <y>".
+func (s Score) UCI() string {
+	if s.IsMate() {
+		return fmt.Sprintf("mate %d", s.MateMoves())
+	}
+	return fmt.Sprintf("cp %d", s)
+}
+
+func (s Score) String() string {
+	return s.UCI()
+}
@@ -0,0 +1,80 @@
+package chess
+
+import (
+	"testing"
+)
+
+func TestMateMoves(t *testing.T) {
+	tests := []struct {
+		score Score
+		moves int
+		uci   string
+	}{
+		{MateIn(1), 1, "mate 1"},
+		{MateIn(3), 2, "mate 2"},
+		{MateIn(5), 3, "mate 3"},
+		{MatedIn(0), 0, "mate 0"},
+		{MatedIn(2), -1, "mate -1"},
+		{MatedIn(4), -2, "mate -2"},
+		{Score(35), 0, "cp 35"},
+		{Score(-120), 0, "cp -120"},
+	}
+
+	for _, test := range tests {
+		if got := test.score.MateMoves(); got != test.moves {
+			t.Errorf("MateMoves(%d) = %d, expected %d", test.score, got, test.moves)
+		}
+		if got := test.score.UCI(); got != test.uci {
+			t.Errorf("UCI(%d) = %q, expected %q", test.score, got, test.uci)
+		}
+	}
+}
+
+func TestScoreTT(t *testing.T) {
+	scores := []Score{MateIn(7), MatedIn(6), 0, 250, -250}
+	for _, s := range scores {
+		for ply := range 20 {
+			stored := s.ToTT(ply)
+			if got := ScoreFromTT(stored, ply); got != s {
+				t.Errorf("TT round trip failed for %d at ply %d, got %d", s, ply, got)
+			}
+		}
+	}
+
+	// a mate found 3 plies below the root is a mate in 2 plies from that node,
+	// loading it 5 plies from the root makes it a mate in 7.
+	stored := MateIn(5).ToTT(3)
+	if stored != MateIn(2) {
+		t.Errorf("expected stored score %d, got %d", MateIn(2), stored)
+	}
+	if got := ScoreFromTT(stored, 5); got != MateIn(7) {
+		t.Errorf("expected loaded score %d, got %d", MateIn(7), got)
+	}
+}
+
+func TestMateDistancePrune(t *testing.T) {
+	// a mate in 1 has already been found, nothing deeper than ply 1 can beat it.
+	alpha, beta := MateIn(1), INF_SCORE
+	_, _, cut := MateDistancePrune(alpha, beta, 4)
+	if !cut {
+		t.Error("expected node to be pruned")
+	}
+
+	alpha, beta, cut = MateDistancePrune(-INF_SCORE, INF_SCORE, 4)
+	if cut {
+		t.Error("did not expect full window to be pruned")
+	}
+	if alpha != MatedIn(4) || beta != MateIn(5) {
+		t.Errorf("unexpected window [%d, %d]", alpha, beta)
+	}
+}
+
+func TestMateWindow(t *testing.T) {
+	alpha, _ := MateWindow(2)
+	if MateIn(3) <= alpha {
+		t.Error("mate in 2 should be inside the window")
+	}
+	if MateIn(5) > alpha {
+		t.Error("mate in 3 should be outside the window")
+	}
+}
@@ -0,0 +1,155 @@
+// Code generated by gentables; DO NOT EDIT.
+
+package chess
+
+// Attacks and moves of the non sliding pieces, indexed by shift.
+var (
+	knightAttacks = [SHIFT_SIZE]BitBoard{
+		0x0000000000020400, 0x0000000000050800, 0x00000000000a1100, 0x0000000000142200,
+		0x0000000000284400, 0x0000000000508800, 0x0000000000a01000, 0x0000000000402000,
+		0x0000000002040004, 0x0000000005080008, 0x000000000a110011, 0x0000000014220022,
+		0x0000000028440044, 0x0000000050880088, 0x00000000a0100010, 0x0000000040200020,
+		0x0000000204000402, 0x0000000508000805, 0x0000000a1100110a, 0x0000001422002214,
+		0x0000002844004428, 0x0000005088008850, 0x000000a0100010a0, 0x0000004020002040,
+		0x0000020400040200, 0x0000050800080500, 0x00000a1100110a00, 0x0000142200221400,
+		0x0000284400442800, 0x0000508800885000, 0x0000a0100010a000, 0x0000402000204000,
+		0x0002040004020000, 0x0005080008050000, 0x000a1100110a0000, 0x0014220022140000,
+		0x0028440044280000, 0x0050880088500000, 0x00a0100010a00000, 0x0040200020400000,
+		0x0204000402000000, 0x0508000805000000, 0x0a1100110a000000, 0x1422002214000000,
+		0x2844004428000000, 0x5088008850000000, 0xa0100010a0000000, 0x4020002040000000,
+		0x0400040200000000, 0x0800080500000000, 0x1100110a00000000, 0x2200221400000000,
+		0x4400442800000000, 0x8800885000000000, 0x100010a000000000, 0x2000204000000000,
+		0x0004020000000000, 0x0008050000000000, 0x00110a0000000000, 0x0022140000000000,
+		0x0044280000000000, 0x0088500000000000, 0x0010a00000000000, 0x0020400000000000,
+	}
+	kingAttacks = [SHIFT_SIZE]BitBoard{
+		0x0000000000000302, 0x0000000000000705, 0x0000000000000e0a, 0x0000000000001c14,
+		0x0000000000003828, 0x0000000000007050, 0x000000000000e0a0, 0x000000000000c040,
+		0x0000000000030203, 0x0000000000070507, 0x00000000000e0a0e, 0x00000000001c141c,
+		0x0000000000382838, 0x0000000000705070, 0x0000000000e0a0e0, 0x0000000000c040c0,
+		0x0000000003020300, 0x0000000007050700, 0x000000000e0a0e00, 0x000000001c141c00,
+		0x0000000038283800, 0x0000000070507000, 0x00000000e0a0e000, 0x00000000c040c000,
+		0x0000000302030000, 0x0000000705070000, 0x0000000e0a0e0000, 0x0000001c141c0000,
+		0x0000003828380000, 0x0000007050700000, 0x000000e0a0e00000, 0x000000c040c00000,
+		0x0000030203000000, 0x0000070507000000, 0x00000e0a0e000000, 0x00001c141c000000,
+		0x0000382838000000, 0x0000705070000000, 0x0000e0a0e0000000, 0x0000c040c0000000,
+		0x0003020300000000, 0x0007050700000000, 0x000e0a0e00000000, 0x001c141c00000000,
+		0x0038283800000000, 0x0070507000000000, 0x00e0a0e000000000, 0x00c040c000000000,
+		0x0302030000000000, 0x0705070000000000, 0x0e0a0e0000000000, 0x1c141c0000000000,
+		0x3828380000000000, 0x7050700000000000, 0xe0a0e00000000000, 0xc040c00000000000,
+		0x0203000000000000, 0x0507000000000000, 0x0a0e000000000000, 0x141c000000000000,
+		0x2838000000000000, 0x5070000000000000, 0xa0e0000000000000, 0x40c0000000000000,
+	}
+	whitePawnMoves = [SHIFT_SIZE]BitBoard{
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+		0x0000000001010000, 0x0000000002020000, 0x0000000004040000, 0x0000000008080000,
+		0x0000000010100000, 0x0000000020200000, 0x0000000040400000, 0x0000000080800000,
+		0x0000000001000000, 0x0000000002000000, 0x0000000004000000, 0x0000000008000000,
+		0x0000000010000000, 0x0000000020000000, 0x0000000040000000, 0x0000000080000000,
+		0x0000000100000000, 0x0000000200000000, 0x0000000400000000, 0x0000000800000000,
+		0x0000001000000000, 0x0000002000000000, 0x0000004000000000, 0x0000008000000000,
+		0x0000010000000000, 0x0000020000000000, 0x0000040000000000, 0x0000080000000000,
+		0x0000100000000000, 0x0000200000000000, 0x0000400000000000, 0x0000800000000000,
+		0x0001000000000000, 0x0002000000000000, 0x0004000000000000, 0x0008000000000000,
+		0x0010000000000000, 0x0020000000000000, 0x0040000000000000, 0x0080000000000000,
+		0x0100000000000000, 0x0200000000000000, 0x0400000000000000, 0x0800000000000000,
+		0x1000000000000000, 0x2000000000000000, 0x4000000000000000, 0x8000000000000000,
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+	}
+	blackPawnMoves = [SHIFT_SIZE]BitBoard{
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+		0x0000000000000001, 0x0000000000000002, 0x0000000000000004, 0x0000000000000008,
+		0x0000000000000010, 0x0000000000000020, 0x0000000000000040, 0x0000000000000080,
+		0x0000000000000100, 0x0000000000000200, 0x0000000000000400, 0x0000000000000800,
+		0x0000000000001000, 0x0000000000002000, 0x0000000000004000, 0x0000000000008000,
+		0x0000000000010000, 0x0000000000020000, 0x0000000000040000, 0x0000000000080000,
+		0x0000000000100000, 0x0000000000200000, 0x0000000000400000, 0x0000000000800000,
+		0x0000000001000000, 0x0000000002000000, 0x0000000004000000, 0x0000000008000000,
+		0x0000000010000000, 0x0000000020000000, 0x0000000040000000, 0x0000000080000000,
+		0x0000000100000000, 0x0000000200000000, 0x0000000400000000, 0x0000000800000000,
+		0x0000001000000000, 0x0000002000000000, 0x0000004000000000, 0x0000008000000000,
+		0x0000010100000000, 0x0000020200000000, 0x0000040400000000, 0x0000080800000000,
+		0x0000101000000000, 0x0000202000000000, 0x0000404000000000, 0x0000808000000000,
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+	}
+	whitePawnAttacks = [SHIFT_SIZE]BitBoard{
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+		0x0000000000020000, 0x0000000000050000, 0x00000000000a0000, 0x0000000000140000,
+		0x0000000000280000, 0x0000000000500000, 0x0000000000a00000, 0x0000000000400000,
+		0x0000000002000000, 0x0000000005000000, 0x000000000a000000, 0x0000000014000000,
+		0x0000000028000000, 0x0000000050000000, 0x00000000a0000000, 0x0000000040000000,
+		0x0000000200000000, 0x0000000500000000, 0x0000000a00000000, 0x0000001400000000,
+		0x0000002800000000, 0x0000005000000000, 0x000000a000000000, 0x0000004000000000,
+		0x0000020000000000, 0x0000050000000000, 0x00000a0000000000, 0x0000140000000000,
+		0x0000280000000000, 0x0000500000000000, 0x0000a00000000000, 0x0000400000000000,
+		0x0002000000000000, 0x0005000000000000, 0x000a000000000000, 0x0014000000000000,
+		0x0028000000000000, 0x0050000000000000, 0x00a0000000000000, 0x0040000000000000,
+		0x0200000000000000, 0x0500000000000000, 0x0a00000000000000, 0x1400000000000000,
+		0x2800000000000000, 0x5000000000000000, 0xa000000000000000, 0x4000000000000000,
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+	}
+	blackPawnAttacks = [SHIFT_SIZE]BitBoard{
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+		0x0000000000000002, 0x0000000000000005, 0x000000000000000a, 0x0000000000000014,
+		0x0000000000000028, 0x0000000000000050, 0x00000000000000a0, 0x0000000000000040,
+		0x0000000000000200, 0x0000000000000500, 0x0000000000000a00, 0x0000000000001400,
+		0x0000000000002800, 0x0000000000005000, 0x000000000000a000, 0x0000000000004000,
+		0x0000000000020000, 0x0000000000050000, 0x00000000000a0000, 0x0000000000140000,
+		0x0000000000280000, 0x0000000000500000, 0x0000000000a00000, 0x0000000000400000,
+		0x0000000002000000, 0x0000000005000000, 0x000000000a000000, 0x0000000014000000,
+		0x0000000028000000, 0x0000000050000000, 0x00000000a0000000, 0x0000000040000000,
+		0x0000000200000000, 0x0000000500000000, 0x0000000a00000000, 0x0000001400000000,
+		0x0000002800000000, 0x0000005000000000, 0x000000a000000000, 0x0000004000000000,
+		0x0000020000000000, 0x0000050000000000, 0x00000a0000000000, 0x0000140000000000,
+		0x0000280000000000, 0x0000500000000000, 0x0000a00000000000, 0x0000400000000000,
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+	}
+)
+
+// Magic numbers of the sliding pieces, indexed by shift.
+var (
+	rookMagics = [SHIFT_SIZE]uint64{
+		0x0100102041008001, 0x3440004820001000, 0x0100200011004008, 0x0480080004100080,
+		0xc600020024085020, 0x0200010408100200, 0x42000800d2000104, 0x010000822a034100,
+		0x030b800288400024, 0x8000802000804003, 0x4c01002001081042, 0x8004801001880280,
+		0x0000800800800400, 0x0006001008160004, 0x2425000200810044, 0x25208000800c5100,
+		0x0080010020804901, 0x0010004000200044, 0x2408110020010048, 0x8080210009001000,
+		0x8484028008008004, 0x0014480104401060, 0x4002040008100142, 0x0010220000489104,
+		0x0000842380004004, 0x4030024540002001, 0x2000100080200081, 0x0080200900100100,
+		0x5040121a00208a00, 0x2003000900020400, 0x0010080400100102, 0x2020004200008401,
+		0x0800400182800020, 0x10e0004000802092, 0x0340200080801000, 0x0400801000800800,
+		0x4200080080800400, 0x2802800200800400, 0x0800020104009028, 0x01c604a102000054,
+		0x8000800040008024, 0x0002004081020020, 0x0006150220010040, 0x1048001000808009,
+		0xc404000408008080, 0x0081004400090002, 0x0040410802040010, 0x008440408c060001,
+		0x8040502100800100, 0x021010c008a000c0, 0x0614402001001100, 0x0050040040080040,
+		0x0d85080004008180, 0x0400800200040080, 0x8101000c02000900, 0x1000008400412200,
+		0x9081102441800105, 0x0000400450218301, 0x0001041088a00041, 0x0500050020100009,
+		0x0011000204080011, 0x00c1000208040001, 0x3940122881300804, 0x8000002100408402,
+	}
+	bishopMagics = [SHIFT_SIZE]uint64{
+		0x4002041000810100, 0x020404080a043000, 0x4022081041880008, 0x0004440280a04000,
+		0x114310c1008400a1, 0x0008240420000000, 0x0a00809048200002, 0x0380220042201000,
+		0x2202400488020060, 0x6922020488021040, 0x0008080824608000, 0x1102045042008004,
+		0x2240411041422028, 0x4c41810460440000, 0x0044b08201104000, 0x0200050c01010801,
+		0x0a1010042430842a, 0x0005829010020842, 0x0104024200340100, 0x2e08801802004480,
+		0x000a000422010224, 0x128120ca02101200, 0x1022140080842001, 0x0808422224260811,
+		0x0104204840082105, 0x0918200004446082, 0x10008200500c0813, 0x0014004004010082,
+		0x0020808010082000, 0x4001060022480401, 0x0802104030880808, 0x7002003902008201,
+		0x4002480465c0501a, 0x0040820900101002, 0x000c04020a410201, 0x8000200800230810,
+		0x1410020080001004, 0x1010830300021000, 0x0054090044020802, 0x4402006a00084604,
+		0x081209202a102040, 0x0000842128012001, 0x3021002101001000, 0x0000002018020100,
+		0x0280010122000400, 0x00c0011102080100, 0x0008980084088480, 0x0008180080851020,
+		0x4400420210400000, 0x0000360806180942, 0x00002024a4100010, 0x462020020504008b,
+		0x118400a020410022, 0x8a50081010008800, 0x2404048842040b98, 0x0902500200810008,
+		0x0003140201042000, 0x0800009208901420, 0x0420000024024801, 0x1800000080420881,
+		0x0080105008102400, 0x000400a004014206, 0x200220a0142189a5, 0x0204101000410048,
+	}
+)
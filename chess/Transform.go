@@ -0,0 +1,94 @@
+package chess
+
+import (
+	"math/bits"
+)
+
+// Flips the board vertically, rank 1 becomes rank 8.
+func (b BitBoard) FlipVertical() BitBoard {
+	return BitBoard(bits.ReverseBytes64(uint64(b)))
+}
+
+// Mirrors the board horizontally, the a file becomes the h file.
+func (b BitBoard) MirrorHorizontal() BitBoard {
+	const k1 BitBoard = 0x5555555555555555
+	const k2 BitBoard = 0x3333333333333333
+	const k4 BitBoard = 0x0f0f0f0f0f0f0f0f
+	b = ((b >> 1) & k1) | ((b & k1) << 1)
+	b = ((b >> 2) & k2) | ((b & k2) << 2)
+	b = ((b >> 4) & k4) | ((b & k4) << 4)
+	return b
+}
+
+// Flips the board about the a1-h8 diagonal, b1 becomes a2.
+func (b BitBoard) FlipDiagonal() BitBoard {
+	const k1 BitBoard = 0x5500550055005500
+	const k2 BitBoard = 0x3333000033330000
+	const k4 BitBoard = 0x0f0f0f0f00000000
+	t := k4 & (b ^ (b << 28))
+	b ^= t ^ (t >> 28)
+	t = k2 & (b ^ (b << 14))
+	b ^= t ^ (t >> 14)
+	t = k1 & (b ^ (b << 7))
+	b ^= t ^ (t >> 7)
+	return b
+}
+
+// Flips the board about the a8-h1 diagonal, a1 becomes h8.
+func (b BitBoard) FlipAntiDiagonal() BitBoard {
+	return b.FlipDiagonal().Rotate180()
+}
+
+// Rotates the board 90 degrees clockwise, a1 becomes a8.
+func (b BitBoard) Rotate90() BitBoard {
+	return b.FlipDiagonal().FlipVertical()
+}
+
+// Rotates the board 180 degrees, a1 becomes h8.
+func (b BitBoard) Rotate180() BitBoard {
+	return BitBoard(bits.Reverse64(uint64(b)))
+}
+
+// Rotates the board 90 degrees anticlockwise, a1 becomes h1.
+func (b BitBoard) Rotate270() BitBoard {
+	return b.FlipVertical().FlipDiagonal()
+}
+
+// Returns the board with the colours swapped and flipped vertically, so the
+// position is the same but from the other players point of view. Castle
+// rights, enpassant and the turn are swapped to match.
+func (b *BoardState) Mirror() *BoardState {
+	m := *b
+	for i := range BLACK_OFFSET {
+		m.pieces[i] = b.pieces[i+BLACK_OFFSET].FlipVertical()
+		m.pieces[i+BLACK_OFFSET] = b.pieces[i].FlipVertical()
+	}
+	m.enpassant = b.enpassant.FlipVertical()
+
+	// white and black castle rights swap, see CASTLE_SYM for the order.
+	castle := b.encoding &^ TURN_MASK
+	turn := (b.encoding ^ TURN_MASK) & TURN_MASK
+	m.encoding = turn | (castle&(WHITEOO_MASK|WHITEOOO_MASK))<<2 | (castle&(BLACKOO_MASK|BLACKOOO_MASK))>>2
+	m.castle_files = [4]uint8{b.castle_files[2], b.castle_files[3], b.castle_files[0], b.castle_files[1]}
+	return &m
+}
+
+// Returns the board mirrored horizontally, the a file becomes the h file.
+// King and queen side castle rights swap.
+func (b *BoardState) FlipHorizontal() *BoardState {
+	m := *b
+	for i := range b.pieces {
+		m.pieces[i] = b.pieces[i].MirrorHorizontal()
+	}
+	m.enpassant = b.enpassant.MirrorHorizontal()
+
+	castle := b.encoding &^ TURN_MASK
+	oo := WHITEOO_MASK | BLACKOO_MASK
+	ooo := WHITEOOO_MASK | BLACKOOO_MASK
+	m.encoding = b.encoding&TURN_MASK | (castle&oo)<<1 | (castle&ooo)>>1
+	for i := 0; i < len(b.castle_files); i += 2 {
+		m.castle_files[i] = ROW_COL_SIZE - 1 - b.castle_files[i+1]
+		m.castle_files[i+1] = ROW_COL_SIZE - 1 - b.castle_files[i]
+	}
+	return &m
+}
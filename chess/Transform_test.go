@@ -0,0 +1,100 @@
+package chess
+
+import (
+	"testing"
+)
+
+func TestBitBoardTransforms(t *testing.T) {
+	tests := []struct {
+		name      string
+		transform func(BitBoard) BitBoard
+		from      string
+		to        string
+	}{
+		{"flip vertical", BitBoard.FlipVertical, "b1", "b8"},
+		{"mirror horizontal", BitBoard.MirrorHorizontal, "b1", "g1"},
+		{"flip diagonal", BitBoard.FlipDiagonal, "b1", "a2"},
+		{"flip anti diagonal", BitBoard.FlipAntiDiagonal, "a1", "h8"},
+		{"flip anti diagonal off axis", BitBoard.FlipAntiDiagonal, "b1", "h7"},
+		{"rotate 90", BitBoard.Rotate90, "a1", "a8"},
+		{"rotate 90 off axis", BitBoard.Rotate90, "b1", "a7"},
+		{"rotate 180", BitBoard.Rotate180, "b1", "g8"},
+		{"rotate 270", BitBoard.Rotate270, "a1", "h1"},
+		{"rotate 270 off axis", BitBoard.Rotate270, "b1", "h2"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			from, _ := LocFromAlg(test.from)
+			to, _ := LocFromAlg(test.to)
+			if got := test.transform(from); got != to {
+				t.Errorf("expected:\n%s\ngot:\n%s", to.String(), got.String())
+			}
+		})
+	}
+
+	boards := []BitBoard{0, 1, 0x8100000000000081, 0x0123456789abcdef, ^BitBoard(0)}
+	for _, b := range boards {
+		if b.FlipVertical().FlipVertical() != b {
+			t.Errorf("FlipVertical is not an involution for %x", uint64(b))
+		}
+		if b.MirrorHorizontal().MirrorHorizontal() != b {
+			t.Errorf("MirrorHorizontal is not an involution for %x", uint64(b))
+		}
+		if b.FlipDiagonal().FlipDiagonal() != b {
+			t.Errorf("FlipDiagonal is not an involution for %x", uint64(b))
+		}
+		if b.Rotate90().Rotate90().Rotate90().Rotate90() != b {
+			t.Errorf("four rotations did not return %x", uint64(b))
+		}
+		if b.Rotate90().Rotate270() != b {
+			t.Errorf("Rotate270 did not undo Rotate90 for %x", uint64(b))
+		}
+		if b.Rotate90().Rotate90() != b.Rotate180() {
+			t.Errorf("two rotations did not match Rotate180 for %x", uint64(b))
+		}
+	}
+}
+
+func TestMirror(t *testing.T) {
+	tests := []struct {
+		fen    string
+		mirror string
+	}{
+		{
+			"rnbqkbnr/ppp2ppp/8/3Pp3/8/8/PPPP1PPP/RNBQKBNR w KQkq e6 0 3",
+			"rnbqkbnr/pppp1ppp/8/8/3pP3/8/PPP2PPP/RNBQKBNR b KQkq e3 0 3",
+		},
+		{
+			"rnbq1bnr/ppp1pkpp/8/3pPp2/8/2N5/PPPP1PPP/R1BQKBNR w KQ d6 0 4",
+			"r1bqkbnr/pppp1ppp/2n5/8/3PpP2/8/PPP1PKPP/RNBQ1BNR b kq d3 0 4",
+		},
+	}
+	for _, test := range tests {
+		b, err := NewBoardFEN(test.fen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m := b.Mirror()
+		if m.FEN() != test.mirror {
+			t.Errorf("expected %s, got %s", test.mirror, m.FEN())
+		}
+		if m.Mirror().FEN() != test.fen {
+			t.Errorf("mirror twice did not return %s, got %s", test.fen, m.Mirror().FEN())
+		}
+	}
+}
+
+func TestFlipHorizontal(t *testing.T) {
+	b, err := NewBoardFEN("r3k2r/pppq1ppp/8/3Pp3/8/8/PPP2PPP/R3K2R w Kq e6 0 3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := b.FlipHorizontal()
+	expected := "r2k3r/ppp1qppp/8/3pP3/8/8/PPP2PPP/R2K3R w Qk d6 0 3"
+	if f.FEN() != expected {
+		t.Errorf("expected %s, got %s", expected, f.FEN())
+	}
+	if f.FlipHorizontal().FEN() != b.FEN() {
+		t.Errorf("flip twice did not return %s, got %s", b.FEN(), f.FlipHorizontal().FEN())
+	}
+}
@@ -2,29 +2,38 @@ package chess
 
 import (
 	"encoding/csv"
-	"log"
+	"errors"
+	"fmt"
 	"os"
 	"strconv"
 )
 
-func LoadAttacks(csv_file_name string) []BitBoard {
+// Loads an attack table from a csv made by gentables. The tables used by the
+// package are in Tables.go, this is kept to check them against the csv files.
+func loadAttacks(csv_file_name string) ([]BitBoard, error) {
 	target := make([]BitBoard, SHIFT_SIZE)
 	data, err := readCSV(csv_file_name)
 	if err != nil {
-		log.Fatalf("Unable to read file: %s", err.Error())
+		return nil, fmt.Errorf("unable to read file: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("no data in file: %s", csv_file_name)
 	}
 
 	for _, record := range data[1:] {
 		val, err := readRecord(record)
 		if err != nil {
-			log.Fatalf("Error in data: %s", err.Error())
+			return nil, fmt.Errorf("error in data: %w", err)
 		}
 		if len(val) != 3 {
-			log.Fatalf("Error, data didnt have enough rows, filename: %s", csv_file_name)
+			return nil, fmt.Errorf("data didnt have enough rows, filename: %s", csv_file_name)
+		}
+		if val[0] >= SHIFT_SIZE {
+			return nil, errors.New("index out of range in " + csv_file_name)
 		}
 		target[val[0]] = BitBoard(val[2])
 	}
-	return target
+	return target, nil
 }
 
 func readCSV(filename string) ([][]string, error) {
@@ -0,0 +1,58 @@
+package chess
+
+import (
+	"math/rand/v2"
+)
+
+// Zobrist keys, generated from a fixed seed when the package is loaded so a
+// hash is the same in every run.
+var (
+	zobristPieces    [12][SHIFT_SIZE]uint64
+	zobristCastle    [4]uint64
+	zobristEnpassant [ROW_COL_SIZE]uint64
+	zobristTurn      uint64
+)
+
+// Returns the Zobrist hash of the board. The en passant square is only hashed
+// when a pawn can capture onto it, so positions that only differ by an unusable
+// en passant square hash the same, as the repetition rules require.
+func (b *BoardState) Hash() uint64 {
+	var hash uint64
+	for i, pieces := range b.pieces {
+		for loc := range pieces.Squares() {
+			hash ^= zobristPieces[i][loc]
+		}
+	}
+	for i := range CASTLE_SYM {
+		if b.encoding&(1<<(i+1)) > 0 {
+			hash ^= zobristCastle[i]
+		}
+	}
+	if b.enpassant > 0 {
+		turn := b.Turn()
+		loc := b.enpassant.LSB()
+		if PawnAttacks(1-turn, loc)&b.GetPieces(turn, PAWN) > 0 {
+			hash ^= zobristEnpassant[loc%ROW_COL_SIZE]
+		}
+	}
+	if b.Turn() == BLACK {
+		hash ^= zobristTurn
+	}
+	return hash
+}
+
+func init() {
+	r := rand.New(rand.NewPCG(1, 0))
+	for i := range zobristPieces {
+		for loc := range zobristPieces[i] {
+			zobristPieces[i][loc] = r.Uint64()
+		}
+	}
+	for i := range zobristCastle {
+		zobristCastle[i] = r.Uint64()
+	}
+	for i := range zobristEnpassant {
+		zobristEnpassant[i] = r.Uint64()
+	}
+	zobristTurn = r.Uint64()
+}
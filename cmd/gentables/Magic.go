@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"math/bits"
+	"math/rand/v2"
+)
+
+// Number of random candidates to try for each square before giving up.
+const MAGIC_LIMIT = 100_000_000
+
+// Returns the index into the attack table of a square for a set of blockers.
+func magicIndex(mask, magic uint64, blockers uint64) uint64 {
+	return ((blockers & mask) * magic) >> (64 - popCount(mask))
+}
+
+// Checks that magic maps every blocker set on the mask to an index with the
+// correct attack, different blocker sets can share an index only if they
+// have the same attack.
+func tryMagic(shift int, mask, magic uint64, r Ray) bool {
+	blockers, attacks := occupancies(shift, mask, r)
+	return checkMagic(mask, magic, blockers, attacks, make([]uint64, len(blockers)))
+}
+
+// Returns every subset of the mask and the attack for each of them.
+func occupancies(shift int, mask uint64, r Ray) ([]uint64, []uint64) {
+	blockers := []uint64{}
+	attacks := []uint64{}
+	var subset uint64 = 0
+	for {
+		blockers = append(blockers, subset)
+		attacks = append(attacks, rayCast(shift, subset, r))
+
+		// Carry-Rippler, step to the next subset of the mask.
+		subset = (subset - mask) & mask
+		if subset == 0 {
+			return blockers, attacks
+		}
+	}
+}
+
+// table is scratch space the size of the attack table, it is cleared here.
+func checkMagic(mask, magic uint64, blockers, attacks, table []uint64) bool {
+	clear(table)
+	for i, subset := range blockers {
+		// sliders always attack at least one square, so 0 marks an empty entry.
+		entry := &table[magicIndex(mask, magic, subset)]
+		if *entry == 0 {
+			*entry = attacks[i]
+		} else if *entry != attacks[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Finds a magic number for a slider on shift.
+func findMagic(rng *rand.Rand, shift int, r Ray) (uint64, error) {
+	mask := magicMask(shift, r)
+	blockers, attacks := occupancies(shift, mask, r)
+	table := make([]uint64, len(blockers))
+	for range MAGIC_LIMIT {
+		// sparse numbers make better magics.
+		magic := rng.Uint64() & rng.Uint64() & rng.Uint64()
+		if bits.OnesCount64((mask*magic)&0xff00000000000000) < 6 {
+			continue
+		}
+		if checkMagic(mask, magic, blockers, attacks, table) {
+			return magic, nil
+		}
+	}
+	return 0, errors.New("hit magic limit, magic not found")
+}
+
+// Finds magic numbers for every square.
+func findMagics(rng *rand.Rand, r Ray) ([]uint64, error) {
+	magics := make([]uint64, SHIFT_SIZE)
+	for shift := range SHIFT_SIZE {
+		magic, err := findMagic(rng, shift, r)
+		if err != nil {
+			return nil, err
+		}
+		magics[shift] = magic
+	}
+	return magics, nil
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"math/bits"
+)
+
+const SHIFT_SIZE = 64
+
+type Colour int
+
+const (
+	WHITE Colour = 0
+	BLACK Colour = 1
+)
+
+const (
+	FILE_A uint64 = 0x0101010101010101
+	FILE_H uint64 = FILE_A << 7
+	RANK_1 uint64 = 0xff
+	RANK_2 uint64 = RANK_1 << 8
+	RANK_7 uint64 = RANK_1 << 48
+	RANK_8 uint64 = RANK_1 << 56
+)
+
+func north(b uint64) uint64 { return b << 8 }
+func south(b uint64) uint64 { return b >> 8 }
+func east(b uint64) uint64  { return (b &^ FILE_H) << 1 }
+func west(b uint64) uint64  { return (b &^ FILE_A) >> 1 }
+
+// Builds a table indexed by shift from a function of the start square.
+func buildTable(generator func(uint64) uint64) []uint64 {
+	table := make([]uint64, SHIFT_SIZE)
+	for shift := range SHIFT_SIZE {
+		table[shift] = generator(1 << shift)
+	}
+	return table
+}
+
+func knightAttack(loc uint64) uint64 {
+	n, s := north(north(loc)), south(south(loc))
+	e, w := east(east(loc)), west(west(loc))
+	return east(n) | west(n) | east(s) | west(s) | north(e) | south(e) | north(w) | south(w)
+}
+
+func kingAttack(loc uint64) uint64 {
+	row := loc | east(loc) | west(loc)
+	return (row | north(row) | south(row)) &^ loc
+}
+
+// Pawns on the first and last rank have no moves, pawns on their starting
+// rank can move two squares.
+func pawnMove(colour Colour) func(uint64) uint64 {
+	return func(loc uint64) uint64 {
+		if loc&(RANK_1|RANK_8) > 0 {
+			return 0
+		}
+		if colour == WHITE {
+			move := north(loc)
+			if loc&RANK_2 > 0 {
+				move |= north(move)
+			}
+			return move
+		}
+		move := south(loc)
+		if loc&RANK_7 > 0 {
+			move |= south(move)
+		}
+		return move
+	}
+}
+
+func pawnAttack(colour Colour) func(uint64) uint64 {
+	return func(loc uint64) uint64 {
+		if loc&(RANK_1|RANK_8) > 0 {
+			return 0
+		}
+		if colour == WHITE {
+			return north(east(loc) | west(loc))
+		}
+		return south(east(loc) | west(loc))
+	}
+}
+
+// Direction vectors (row, col) for the sliding pieces.
+type Ray [4][2]int
+
+var (
+	ROOK_RAY   = Ray{{1, 0}, {-1, 0}, {0, 1}, {0, -1}}
+	BISHOP_RAY = Ray{{1, 1}, {-1, -1}, {1, -1}, {-1, 1}}
+)
+
+// Casts rays from shift until they leave the board or hit a blocker, the
+// blocker is included in the attack.
+func rayCast(shift int, blockers uint64, r Ray) uint64 {
+	row, col := shift/8, shift%8
+	var attacks uint64 = 0
+	for _, direction := range r {
+		rayRow, rayCol := row+direction[0], col+direction[1]
+		for rayRow >= 0 && rayRow < 8 && rayCol >= 0 && rayCol < 8 {
+			loc := uint64(1) << (rayCol + rayRow*8)
+			attacks |= loc
+			if blockers&loc > 0 {
+				break
+			}
+			rayRow += direction[0]
+			rayCol += direction[1]
+		}
+	}
+	return attacks
+}
+
+// Returns the squares whose occupancy changes the attack of a slider on
+// shift, the edge of the board is left out since it never blocks anything.
+func magicMask(shift int, r Ray) uint64 {
+	row := RANK_1 << (8 * (shift / 8))
+	col := FILE_A << (shift % 8)
+	edges := ((RANK_1 | RANK_8) &^ row) | ((FILE_A | FILE_H) &^ col)
+	return rayCast(shift, 0, r) &^ edges
+}
+
+func popCount(b uint64) int {
+	return bits.OnesCount64(b)
+}
@@ -0,0 +1,124 @@
+// Gentables generates the precomputed attack tables and magic numbers used by
+// the chess package. It is run by go generate in the chess directory:
+//
+//	go run ../cmd/gentables -out Tables.go
+//
+// With -csv the tables are also written as csv files for checking by hand.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+)
+
+// Table is a generated table, Name is the Go variable and File the csv name.
+type Table struct {
+	Name   string
+	File   string
+	Values []uint64
+}
+
+// Returns the attack and move tables for the non sliding pieces.
+func attackTables() []Table {
+	return []Table{
+		{"knightAttacks", "knight_attacks.csv", buildTable(knightAttack)},
+		{"kingAttacks", "king_attacks.csv", buildTable(kingAttack)},
+		{"whitePawnMoves", "white_pawn_move.csv", buildTable(pawnMove(WHITE))},
+		{"blackPawnMoves", "black_pawn_move.csv", buildTable(pawnMove(BLACK))},
+		{"whitePawnAttacks", "white_pawn_attacks.csv", buildTable(pawnAttack(WHITE))},
+		{"blackPawnAttacks", "black_pawn_attacks.csv", buildTable(pawnAttack(BLACK))},
+	}
+}
+
+// Returns the magic numbers for rooks and bishops, the seed keeps the output
+// the same between runs.
+func magicTables(seed uint64) ([]Table, error) {
+	rng := rand.New(rand.NewPCG(seed, seed))
+	rook, err := findMagics(rng, ROOK_RAY)
+	if err != nil {
+		return nil, fmt.Errorf("rook magics: %w", err)
+	}
+	bishop, err := findMagics(rng, BISHOP_RAY)
+	if err != nil {
+		return nil, fmt.Errorf("bishop magics: %w", err)
+	}
+	return []Table{
+		{"rookMagics", "", rook},
+		{"bishopMagics", "", bishop},
+	}, nil
+}
+
+// Writes the Go source for the tables.
+func generateSource(attacks, magics []Table) ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString("// Code generated by gentables; DO NOT EDIT.\n\n")
+	buffer.WriteString("package chess\n\n")
+
+	writeTables := func(comment, elem string, tables []Table) {
+		buffer.WriteString(comment)
+		buffer.WriteString("var (\n")
+		for _, table := range tables {
+			fmt.Fprintf(&buffer, "%s = [SHIFT_SIZE]%s{\n", table.Name, elem)
+			for i, v := range table.Values {
+				fmt.Fprintf(&buffer, "0x%016x,", v)
+				if i%4 == 3 {
+					buffer.WriteRune('\n')
+				} else {
+					buffer.WriteRune(' ')
+				}
+			}
+			buffer.WriteString("}\n")
+		}
+		buffer.WriteString(")\n\n")
+	}
+	writeTables("// Attacks and moves of the non sliding pieces, indexed by shift.\n", "BitBoard", attacks)
+	writeTables("// Magic numbers of the sliding pieces, indexed by shift.\n", "uint64", magics)
+
+	return format.Source(buffer.Bytes())
+}
+
+// Writes a table as a csv, the layout matches the files in data/.
+func generateCSV(table Table) []byte {
+	var buffer bytes.Buffer
+	buffer.WriteString(",start,move\n")
+	for i, v := range table.Values {
+		fmt.Fprintf(&buffer, "%d,%d,%d\n", i, uint64(1)<<i, v)
+	}
+	return buffer.Bytes()
+}
+
+func main() {
+	out := flag.String("out", "Tables.go", "path of the generated Go file")
+	csv_dir := flag.String("csv", "", "directory to also write the tables as csv files")
+	seed := flag.Uint64("seed", 1, "seed for the magic number search")
+	flag.Parse()
+
+	attacks := attackTables()
+	magics, err := magicTables(*seed)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	source, err := generateSource(attacks, magics)
+	if err != nil {
+		log.Fatalf("could not format generated source: %s", err)
+	}
+	if err := os.WriteFile(*out, source, 0644); err != nil {
+		log.Fatal(err)
+	}
+
+	if *csv_dir != "" {
+		for _, table := range attacks {
+			path := filepath.Join(*csv_dir, table.File)
+			if err := os.WriteFile(path, generateCSV(table), 0644); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTablesMatchCSV(t *testing.T) {
+	for _, table := range attackTables() {
+		expected, err := os.ReadFile(filepath.Join("..", "..", "data", table.File))
+		if err != nil {
+			t.Fatalf("could not read %s: %v", table.File, err)
+		}
+		if !bytes.Equal(generateCSV(table), expected) {
+			t.Errorf("generated %s does not match data/%s", table.Name, table.File)
+		}
+	}
+}
+
+func TestMagics(t *testing.T) {
+	magics, err := magicTables(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rays := []Ray{ROOK_RAY, BISHOP_RAY}
+	for i, table := range magics {
+		for shift, magic := range table.Values {
+			if !tryMagic(shift, magicMask(shift, rays[i]), magic, rays[i]) {
+				t.Errorf("%s has an invalid magic for shift %d", table.Name, shift)
+			}
+		}
+	}
+
+	// the checked in tables should be what the generator makes.
+	source, err := generateSource(attackTables(), magics)
+	if err != nil {
+		t.Fatal(err)
+	}
+	current, err := os.ReadFile(filepath.Join("..", "..", "chess", "Tables.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(source, current) {
+		t.Error("chess/Tables.go is out of date, run go generate in chess/")
+	}
+}
+
+func TestMagicMask(t *testing.T) {
+	tests := []struct {
+		shift int
+		ray   Ray
+		bits  int
+	}{
+		{0, ROOK_RAY, 12},
+		{27, ROOK_RAY, 10},
+		{63, ROOK_RAY, 12},
+		{0, BISHOP_RAY, 6},
+		{27, BISHOP_RAY, 9},
+	}
+	for _, test := range tests {
+		mask := magicMask(test.shift, test.ray)
+		if popCount(mask) != test.bits {
+			t.Errorf("mask for shift %d has %d bits, expected %d", test.shift, popCount(mask), test.bits)
+		}
+		if mask&(1<<test.shift) != 0 {
+			t.Errorf("mask for shift %d includes the source square", test.shift)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Package diagram draws chess boards as SVG and PNG images.
+package diagram
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/ethankuehler/gochess/chess"
+)
+
+const DEFAULT_SIZE = 400
+
+// Colours used for the board, pieces and markings. The alpha is not
+// premultiplied, it is the opacity of the colour when drawn over the board.
+var (
+	LIGHT_SQUARE = color.RGBA{0xf0, 0xd9, 0xb5, 0xff}
+	DARK_SQUARE  = color.RGBA{0xb5, 0x88, 0x63, 0xff}
+	WHITE_PIECE  = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	BLACK_PIECE  = color.RGBA{0x22, 0x22, 0x22, 0xff}
+	OUTLINE      = color.RGBA{0x00, 0x00, 0x00, 0xff}
+	HIGHLIGHT    = color.RGBA{0xff, 0xee, 0x00, 0x80}
+	ARROW        = color.RGBA{0x15, 0x78, 0x1b, 0xb0}
+)
+
+// Arrow from one square to another, drawn over the pieces.
+type Arrow struct {
+	From chess.Shift
+	To   chess.Shift
+}
+
+// Options for drawing a board, the zero value is a plain 400 pixel board
+// with white at the bottom.
+type Options struct {
+	Size        int            // width and height in pixels, DEFAULT_SIZE if 0
+	Perspective chess.Colour   // colour at the bottom of the board
+	Coordinates bool           // draw file and rank labels on the edge squares
+	Highlight   chess.BitBoard // squares to highlight
+	Arrows      []Arrow
+}
+
+func (opts Options) size() int {
+	if opts.Size <= 0 {
+		return DEFAULT_SIZE
+	}
+	return opts.Size
+}
+
+// Returns the size of a square in pixels.
+func (opts Options) square() float64 {
+	return float64(opts.size()) / 8
+}
+
+// Returns the top left corner of a square in pixels.
+func (opts Options) corner(loc chess.Shift) (float64, float64) {
+	row, col := int(loc)/8, int(loc)%8
+	if opts.Perspective == chess.BLACK {
+		col = 7 - col
+	} else {
+		row = 7 - row
+	}
+	return float64(col) * opts.square(), float64(row) * opts.square()
+}
+
+// Returns the centre of a square in pixels.
+func (opts Options) centre(loc chess.Shift) (float64, float64) {
+	x, y := opts.corner(loc)
+	return x + opts.square()/2, y + opts.square()/2
+}
+
+func isLight(loc chess.Shift) bool {
+	return (int(loc)/8+int(loc)%8)%2 == 1
+}
+
+// Returns the outline of an arrow as a polygon in pixels.
+func (opts Options) arrowPolygon(arrow Arrow) polygon {
+	x0, y0 := opts.centre(arrow.From)
+	x1, y1 := opts.centre(arrow.To)
+	dx, dy := x1-x0, y1-y0
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return nil
+	}
+	// unit vector along the arrow and the normal to it.
+	ux, uy := dx/length, dy/length
+	nx, ny := -uy, ux
+
+	sq := opts.square()
+	shaft := sq * 0.1
+	head := sq * 0.25
+	headLength := sq * 0.4
+	// stop the shaft where the head starts.
+	bx, by := x1-ux*headLength, y1-uy*headLength
+	return polygon{
+		{x0 + nx*shaft, y0 + ny*shaft},
+		{bx + nx*shaft, by + ny*shaft},
+		{bx + nx*head, by + ny*head},
+		{x1, y1},
+		{bx - nx*head, by - ny*head},
+		{bx - nx*shaft, by - ny*shaft},
+		{x0 - nx*shaft, y0 - ny*shaft},
+	}
+}
+
+// Returns a piece polygon moved and scaled into the square with corner x, y.
+func (p polygon) place(x, y, scale float64) polygon {
+	out := make(polygon, len(p))
+	for i, pt := range p {
+		out[i] = point{x + pt.x*scale, y + pt.y*scale}
+	}
+	return out
+}
@@ -0,0 +1,99 @@
+package diagram
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/ethankuehler/gochess/chess"
+)
+
+func TestSVG(t *testing.T) {
+	b := chess.NewBoardDefault()
+	e2, _ := chess.ShiftFromAlg("e2")
+	e4, _ := chess.ShiftFromAlg("e4")
+	highlight, _ := chess.SquaresToBitBoard([]string{"e2", "e4"})
+	opts := Options{Coordinates: true, Highlight: highlight, Arrows: []Arrow{{e2, e4}}}
+
+	var buffer bytes.Buffer
+	if err := SVG(&buffer, b, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	var svg struct {
+		Rects    []struct{} `xml:"rect"`
+		Texts    []string   `xml:"text"`
+		Groups   []struct{} `xml:"g"`
+		Polygons []struct{} `xml:"polygon"`
+	}
+	if err := xml.Unmarshal(buffer.Bytes(), &svg); err != nil {
+		t.Fatalf("invalid SVG: %v", err)
+	}
+	if len(svg.Rects) != 64+2 {
+		t.Errorf("expected 66 rects, got %d", len(svg.Rects))
+	}
+	if len(svg.Groups) != 32 {
+		t.Errorf("expected 32 pieces, got %d", len(svg.Groups))
+	}
+	if len(svg.Texts) != 16 || svg.Texts[0] != "a" || svg.Texts[8] != "1" {
+		t.Errorf("unexpected coordinates %v", svg.Texts)
+	}
+	if len(svg.Polygons) != 1 {
+		t.Errorf("expected 1 arrow, got %d", len(svg.Polygons))
+	}
+}
+
+func TestPNG(t *testing.T) {
+	b, _ := chess.NewBoardFEN("4k3/8/8/8/8/8/8/4K3 w - - 0 1")
+
+	var buffer bytes.Buffer
+	if err := PNG(&buffer, b, Options{Size: 160}); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&buffer)
+	if err != nil {
+		t.Fatalf("invalid PNG: %v", err)
+	}
+	if img.Bounds().Dx() != 160 || img.Bounds().Dy() != 160 {
+		t.Fatalf("unexpected size %v", img.Bounds())
+	}
+
+	// a1 is dark and in the bottom left, a8 is light and in the top left.
+	a1 := img.At(10, 150)
+	if a1 != DARK_SQUARE {
+		t.Errorf("expected a dark square at a1, got %v", a1)
+	}
+	a8 := img.At(10, 10)
+	if a8 != LIGHT_SQUARE {
+		t.Errorf("expected a light square at a8, got %v", a8)
+	}
+	// the middle of the white king's body.
+	if king := img.At(4*20+10, 7*20+11); king != WHITE_PIECE {
+		t.Errorf("expected a white piece on e1, got %v", king)
+	}
+
+	// from black's side e1 is in the top right half.
+	flipped := Image(b, Options{Size: 160, Perspective: chess.BLACK})
+	if king := flipped.At(3*20+10, 0*20+11); king != WHITE_PIECE {
+		t.Errorf("expected a white piece on e1 from black's side, got %v", king)
+	}
+}
+
+func TestArrowPolygon(t *testing.T) {
+	e2, _ := chess.ShiftFromAlg("e2")
+	e4, _ := chess.ShiftFromAlg("e4")
+	opts := Options{}
+	if opts.arrowPolygon(Arrow{e2, e2}) != nil {
+		t.Error("expected no arrow from a square to itself")
+	}
+	arrow := opts.arrowPolygon(Arrow{e2, e4})
+	x, y := opts.centre(e4)
+	if arrow[3] != (point{x, y}) {
+		t.Errorf("arrow tip should be at the centre of e4, got %v", arrow[3])
+	}
+	if !strings.Contains(arrow.svgPoints(), ",") {
+		t.Error("expected svg points")
+	}
+}
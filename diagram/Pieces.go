@@ -0,0 +1,88 @@
+package diagram
+
+import (
+	"math"
+
+	"github.com/ethankuehler/gochess/chess"
+)
+
+// point in a piece's own coordinates, a square is 100 by 100 with y going down.
+type point struct {
+	x, y float64
+}
+
+type polygon []point
+
+func rect(x0, y0, x1, y1 float64) polygon {
+	return polygon{{x0, y0}, {x1, y0}, {x1, y1}, {x0, y1}}
+}
+
+func circle(cx, cy, r float64) polygon {
+	const steps = 24
+	p := make(polygon, steps)
+	for i := range steps {
+		angle := 2 * math.Pi * float64(i) / steps
+		p[i] = point{cx + r*math.Cos(angle), cy + r*math.Sin(angle)}
+	}
+	return p
+}
+
+// base shared by every piece.
+var base = polygon{{24, 84}, {76, 84}, {76, 76}, {70, 70}, {30, 70}, {24, 76}}
+
+// Silhouettes of each piece, indexed like chess.Piece. Each piece is drawn as
+// a list of filled and outlined polygons, painted in order.
+var PIECE_SHAPES = [6][]polygon{
+	chess.PAWN: {
+		base,
+		{{42, 44}, {58, 44}, {64, 70}, {36, 70}},
+		circle(50, 34, 12),
+	},
+	chess.BISHOP: {
+		base,
+		{{50, 20}, {62, 34}, {62, 46}, {56, 56}, {60, 70}, {40, 70}, {44, 56}, {38, 46}, {38, 34}},
+		circle(50, 16, 5),
+	},
+	chess.KNIGHT: {
+		base,
+		{
+			{30, 70}, {40, 56}, {46, 46}, {36, 50}, {28, 54}, {24, 46}, {34, 32},
+			{44, 22}, {48, 14}, {54, 20}, {64, 26}, {70, 40}, {72, 56}, {70, 70},
+		},
+	},
+	chess.ROOK: {
+		base,
+		{{34, 40}, {66, 40}, {64, 70}, {36, 70}},
+		{
+			{28, 22}, {37, 22}, {37, 29}, {45, 29}, {45, 22}, {55, 22}, {55, 29},
+			{63, 29}, {63, 22}, {72, 22}, {72, 40}, {28, 40},
+		},
+	},
+	chess.QUEEN: {
+		base,
+		{
+			{24, 30}, {36, 52}, {38, 24}, {46, 50}, {50, 18}, {54, 50}, {62, 24},
+			{64, 52}, {76, 30}, {66, 70}, {34, 70},
+		},
+		circle(24, 28, 4), circle(38, 22, 4), circle(50, 16, 4), circle(62, 22, 4), circle(76, 28, 4),
+	},
+	chess.KING: {
+		base,
+		{{30, 38}, {70, 38}, {64, 70}, {36, 70}},
+		rect(46, 8, 54, 36),
+		rect(38, 15, 62, 23),
+	},
+}
+
+// Returns the piece on a square, ok is false for an empty square.
+func pieceAt(b *chess.BoardState, loc chess.Shift) (chess.Colour, chess.Piece, bool) {
+	mask := chess.BitBoard(1) << loc
+	for _, colour := range []chess.Colour{chess.WHITE, chess.BLACK} {
+		for piece := chess.PAWN; piece <= chess.KING; piece++ {
+			if b.GetPieces(colour, piece)&mask > 0 {
+				return colour, piece, true
+			}
+		}
+	}
+	return chess.WHITE, chess.PAWN, false
+}
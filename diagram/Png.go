@@ -0,0 +1,196 @@
+package diagram
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+
+	"github.com/ethankuehler/gochess/chess"
+)
+
+// Samples per pixel along each axis when filling polygons, for anti aliasing.
+const SUPERSAMPLE = 4
+
+// Draws the board into a new image.
+func Image(b *chess.BoardState, opts Options) *image.RGBA {
+	size := opts.size()
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	sq := opts.square()
+
+	for loc := range chess.Shift(chess.SHIFT_SIZE) {
+		x, y := opts.corner(loc)
+		bounds := image.Rect(int(math.Round(x)), int(math.Round(y)), int(math.Round(x+sq)), int(math.Round(y+sq)))
+		fill := DARK_SQUARE
+		if isLight(loc) {
+			fill = LIGHT_SQUARE
+		}
+		draw.Draw(img, bounds, image.NewUniform(fill), image.Point{}, draw.Src)
+		if opts.Highlight&(1<<loc) > 0 {
+			fillPolygon(img, rect(x, y, x+sq, y+sq), HIGHLIGHT, 0, HIGHLIGHT)
+		}
+	}
+
+	if opts.Coordinates {
+		// glyphs are 5 pixels high, scale them to about a fifth of a square.
+		scale := max(1, int(sq/25))
+		for _, l := range opts.labels() {
+			drawText(img, l.text, int(l.x), int(l.y), scale, l.colour())
+		}
+	}
+
+	scale := sq / 100
+	for loc := range chess.Shift(chess.SHIFT_SIZE) {
+		colour, piece, ok := pieceAt(b, loc)
+		if !ok {
+			continue
+		}
+		fill := WHITE_PIECE
+		if colour == chess.BLACK {
+			fill = BLACK_PIECE
+		}
+		x, y := opts.corner(loc)
+		for _, shape := range PIECE_SHAPES[piece] {
+			fillPolygon(img, shape.place(x, y, scale), fill, OUTLINE_WIDTH*scale, OUTLINE)
+		}
+	}
+
+	for _, arrow := range opts.Arrows {
+		if shape := opts.arrowPolygon(arrow); shape != nil {
+			fillPolygon(img, shape, ARROW, 0, ARROW)
+		}
+	}
+	return img
+}
+
+// Writes the board as a PNG image.
+func PNG(w io.Writer, b *chess.BoardState, opts Options) error {
+	return png.Encode(w, Image(b, opts))
+}
+
+// Fills a polygon with an outline of the given width, both are blended over
+// the image using the coverage of each pixel.
+func fillPolygon(img *image.RGBA, p polygon, fill color.RGBA, width float64, outline color.RGBA) {
+	minX, minY, maxX, maxY := p.bounds()
+	half := width / 2
+	x0, y0 := int(math.Floor(minX-half)), int(math.Floor(minY-half))
+	x1, y1 := int(math.Ceil(maxX+half)), int(math.Ceil(maxY+half))
+	bounds := image.Rect(x0, y0, x1, y1).Intersect(img.Bounds())
+
+	const samples = SUPERSAMPLE * SUPERSAMPLE
+	for py := bounds.Min.Y; py < bounds.Max.Y; py++ {
+		for px := bounds.Min.X; px < bounds.Max.X; px++ {
+			fillCount, outlineCount := 0, 0
+			for sy := range SUPERSAMPLE {
+				for sx := range SUPERSAMPLE {
+					pt := point{
+						float64(px) + (float64(sx)+0.5)/SUPERSAMPLE,
+						float64(py) + (float64(sy)+0.5)/SUPERSAMPLE,
+					}
+					if width > 0 && p.distance(pt) <= half {
+						outlineCount++
+					} else if p.contains(pt) {
+						fillCount++
+					}
+				}
+			}
+			if fillCount > 0 {
+				blend(img, px, py, fill, float64(fillCount)/samples)
+			}
+			if outlineCount > 0 {
+				blend(img, px, py, outline, float64(outlineCount)/samples)
+			}
+		}
+	}
+}
+
+// Blends c over the pixel at x, y with the given coverage.
+func blend(img *image.RGBA, x, y int, c color.RGBA, coverage float64) {
+	alpha := float64(c.A) / 0xff * coverage
+	dst := img.RGBAAt(x, y)
+	mix := func(d, s uint8) uint8 {
+		return uint8(math.Round(float64(d)*(1-alpha) + float64(s)*alpha))
+	}
+	img.SetRGBA(x, y, color.RGBA{mix(dst.R, c.R), mix(dst.G, c.G), mix(dst.B, c.B), 0xff})
+}
+
+func (p polygon) bounds() (float64, float64, float64, float64) {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, pt := range p {
+		minX, minY = min(minX, pt.x), min(minY, pt.y)
+		maxX, maxY = max(maxX, pt.x), max(maxY, pt.y)
+	}
+	return minX, minY, maxX, maxY
+}
+
+// Even odd test for whether pt is inside the polygon.
+func (p polygon) contains(pt point) bool {
+	inside := false
+	for i, j := 0, len(p)-1; i < len(p); j, i = i, i+1 {
+		a, b := p[i], p[j]
+		if (a.y > pt.y) != (b.y > pt.y) && pt.x < (b.x-a.x)*(pt.y-a.y)/(b.y-a.y)+a.x {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// Returns the distance from pt to the closest edge of the polygon.
+func (p polygon) distance(pt point) float64 {
+	closest := math.Inf(1)
+	for i, j := 0, len(p)-1; i < len(p); j, i = i, i+1 {
+		a, b := p[j], p[i]
+		dx, dy := b.x-a.x, b.y-a.y
+		t := 0.0
+		if length := dx*dx + dy*dy; length > 0 {
+			t = ((pt.x-a.x)*dx + (pt.y-a.y)*dy) / length
+			t = min(max(t, 0), 1)
+		}
+		closest = min(closest, math.Hypot(pt.x-(a.x+t*dx), pt.y-(a.y+t*dy)))
+	}
+	return closest
+}
+
+// 3 by 5 pixel glyphs for the coordinate labels.
+var GLYPHS = map[rune][5]string{
+	'a': {"###", "..#", "###", "#.#", "###"},
+	'b': {"#..", "#..", "###", "#.#", "###"},
+	'c': {"...", "###", "#..", "#..", "###"},
+	'd': {"..#", "..#", "###", "#.#", "###"},
+	'e': {"###", "#.#", "###", "#..", "###"},
+	'f': {".##", "#..", "###", "#..", "#.."},
+	'g': {"###", "#.#", "###", "..#", "###"},
+	'h': {"#..", "#..", "###", "#.#", "#.#"},
+	'1': {".#.", "##.", ".#.", ".#.", "###"},
+	'2': {"###", "..#", "###", "#..", "###"},
+	'3': {"###", "..#", "###", "..#", "###"},
+	'4': {"#.#", "#.#", "###", "..#", "..#"},
+	'5': {"###", "#..", "###", "..#", "###"},
+	'6': {"###", "#..", "###", "#.#", "###"},
+	'7': {"###", "..#", "..#", "..#", "..#"},
+	'8': {"###", "#.#", "###", "#.#", "###"},
+}
+
+// Draws text with the top left corner at x, y, each glyph pixel is scale
+// pixels wide.
+func drawText(img *image.RGBA, text string, x, y, scale int, c color.RGBA) {
+	for _, r := range text {
+		glyph, ok := GLYPHS[r]
+		if !ok {
+			continue
+		}
+		for row, line := range glyph {
+			for col, v := range line {
+				if v != '#' {
+					continue
+				}
+				px, py := x+col*scale, y+row*scale
+				draw.Draw(img, image.Rect(px, py, px+scale, py+scale), image.NewUniform(c), image.Point{}, draw.Src)
+			}
+		}
+		x += 4 * scale
+	}
+}
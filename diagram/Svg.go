@@ -0,0 +1,130 @@
+package diagram
+
+import (
+	"bufio"
+	"fmt"
+	"image/color"
+	"io"
+	"strings"
+
+	"github.com/ethankuehler/gochess/chess"
+)
+
+// Width of the piece outlines in piece coordinates.
+const OUTLINE_WIDTH = 2.5
+
+// Writes the board as an SVG image.
+func SVG(w io.Writer, b *chess.BoardState, opts Options) error {
+	out := bufio.NewWriter(w)
+	size := opts.size()
+	sq := opts.square()
+
+	fmt.Fprintf(out, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		size, size, size, size)
+
+	for loc := range chess.Shift(chess.SHIFT_SIZE) {
+		x, y := opts.corner(loc)
+		fill := DARK_SQUARE
+		if isLight(loc) {
+			fill = LIGHT_SQUARE
+		}
+		fmt.Fprintf(out, `<rect x="%g" y="%g" width="%g" height="%g" %s/>`+"\n", x, y, sq, sq, svgFill(fill))
+		if opts.Highlight&(1<<loc) > 0 {
+			fmt.Fprintf(out, `<rect x="%g" y="%g" width="%g" height="%g" %s/>`+"\n", x, y, sq, sq, svgFill(HIGHLIGHT))
+		}
+	}
+
+	if opts.Coordinates {
+		for _, l := range opts.labels() {
+			fmt.Fprintf(out, `<text x="%g" y="%g" font-family="sans-serif" font-size="%g" %s>%s</text>`+"\n",
+				l.x, l.y+sq*0.16, sq*0.18, svgFill(l.colour()), l.text)
+		}
+	}
+
+	scale := sq / 100
+	for loc := range chess.Shift(chess.SHIFT_SIZE) {
+		colour, piece, ok := pieceAt(b, loc)
+		if !ok {
+			continue
+		}
+		fill := WHITE_PIECE
+		if colour == chess.BLACK {
+			fill = BLACK_PIECE
+		}
+		x, y := opts.corner(loc)
+		fmt.Fprintf(out, `<g %s stroke="%s" stroke-width="%g" stroke-linejoin="round">`+"\n",
+			svgFill(fill), svgColour(OUTLINE), OUTLINE_WIDTH*scale)
+		for _, shape := range PIECE_SHAPES[piece] {
+			fmt.Fprintf(out, `<polygon points="%s"/>`+"\n", shape.place(x, y, scale).svgPoints())
+		}
+		out.WriteString("</g>\n")
+	}
+
+	for _, arrow := range opts.Arrows {
+		shape := opts.arrowPolygon(arrow)
+		if shape == nil {
+			continue
+		}
+		fmt.Fprintf(out, `<polygon points="%s" %s/>`+"\n", shape.svgPoints(), svgFill(ARROW))
+	}
+
+	out.WriteString("</svg>\n")
+	return out.Flush()
+}
+
+// label is a coordinate drawn in the corner of an edge square, x and y are
+// its top left corner in pixels.
+type label struct {
+	text  string
+	x, y  float64
+	light bool // drawn on a light square
+}
+
+// Returns the coordinate labels, files go on the bottom rank and ranks on the
+// left file.
+func (opts Options) labels() []label {
+	bottom, left := 0, 0
+	if opts.Perspective == chess.BLACK {
+		bottom, left = 7, 7
+	}
+	sq := opts.square()
+	labels := []label{}
+	for col := range 8 {
+		loc := chess.Shift(bottom*8 + col)
+		x, y := opts.corner(loc)
+		labels = append(labels, label{string(chess.COLUMNS[col]), x + sq*0.82, y + sq*0.78, isLight(loc)})
+	}
+	for row := range 8 {
+		loc := chess.Shift(row*8 + left)
+		x, y := opts.corner(loc)
+		labels = append(labels, label{fmt.Sprint(row + 1), x + sq*0.04, y + sq*0.04, isLight(loc)})
+	}
+	return labels
+}
+
+// Labels use the colour of the other squares so they stand out.
+func (l label) colour() color.RGBA {
+	if l.light {
+		return DARK_SQUARE
+	}
+	return LIGHT_SQUARE
+}
+
+func (p polygon) svgPoints() string {
+	points := make([]string, len(p))
+	for i, pt := range p {
+		points[i] = fmt.Sprintf("%.2f,%.2f", pt.x, pt.y)
+	}
+	return strings.Join(points, " ")
+}
+
+func svgColour(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func svgFill(c color.RGBA) string {
+	if c.A == 0xff {
+		return fmt.Sprintf(`fill="%s"`, svgColour(c))
+	}
+	return fmt.Sprintf(`fill="%s" fill-opacity="%.2f"`, svgColour(c), float64(c.A)/0xff)
+}
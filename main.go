@@ -1,25 +1,29 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"log"
+	"net/http"
+	"os"
 
 	"github.com/ethankuehler/gochess/chess"
+	"github.com/ethankuehler/gochess/server"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		serve(os.Args[2:])
+		return
+	}
 
-	chess.BuildKingAttacks()
-	chess.BuildKnightAttacks()
-	chess.BuildPawnAttacks()
-	chess.BuildPawnMoves()
-	//fmt.Println(chess.WHITE_PAWN_ATTACKS)
-	//fmt.Println(chess.KNIGHT_ATTACKS)
 	b, err := chess.NewBoardFEN("rnbqkb1r/1p2pppp/p2p1n2/8/3NP3/2N5/PPP2PPP/R1BQKB1R w KQkq - 0 6")
 	//b, err := chess.NewBoardFEN("rnbqkbnr/ppp2ppp/8/3Pp3/8/8/PPPP1PPP/RNBQKBNR w KQkq e6 0 3")
 	if err != nil {
 		fmt.Println(err)
 	}
-	fmt.Println(b.StringUni())
+	b.Render(os.Stdout, chess.RenderOptions{Unicode: true, Labels: true, Colour: true, Info: true})
+	fmt.Println()
 	var bb chess.BitBoard = 0
 	for i := range chess.PiecesIter(chess.WHITE) {
 		bb |= b.GetPieces(chess.WHITE, i)
@@ -31,3 +35,13 @@ func main() {
 	}
 	fmt.Println(m)
 }
+
+// Runs the HTTP/JSON API, see the server package.
+func serve(args []string) {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := flags.String("addr", "localhost:8080", "address to listen on")
+	flags.Parse(args)
+
+	log.Printf("listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, server.NewHandler()))
+}
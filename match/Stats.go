@@ -0,0 +1,109 @@
+package match
+
+import (
+	"math"
+)
+
+// Result is the tally of a match from the point of view of the first engine.
+type Result struct {
+	Wins   int
+	Draws  int
+	Losses int
+}
+
+func (r Result) Games() int {
+	return r.Wins + r.Draws + r.Losses
+}
+
+// Returns the average score per game, a win is 1 and a draw is 0.5.
+func (r Result) Score() float64 {
+	return (float64(r.Wins) + float64(r.Draws)/2) / float64(r.Games())
+}
+
+// Returns the per game variance of the score.
+func (r Result) variance() float64 {
+	n := float64(r.Games())
+	s := r.Score()
+	w, d, l := float64(r.Wins)/n, float64(r.Draws)/n, float64(r.Losses)/n
+	return w*(1-s)*(1-s) + d*(0.5-s)*(0.5-s) + l*s*s
+}
+
+// Converts an expected score to an Elo difference with the logistic model.
+func EloFromScore(score float64) float64 {
+	return -400 * math.Log10(1/score-1)
+}
+
+// Converts an Elo difference to an expected score with the logistic model.
+func ScoreFromElo(elo float64) float64 {
+	return 1 / (1 + math.Pow(10, -elo/400))
+}
+
+// Returns the Elo difference and the 95% error margin of the result.
+// Returns infinities if one engine won or lost every game.
+func (r Result) Elo() (float64, float64) {
+	switch r.Games() {
+	case 0:
+		return 0, 0
+	case r.Wins:
+		return math.Inf(1), math.Inf(1)
+	case r.Losses:
+		return math.Inf(-1), math.Inf(1)
+	}
+	s := r.Score()
+	margin := 1.959964 * math.Sqrt(r.variance()/float64(r.Games()))
+	low := EloFromScore(max(s-margin, 0))
+	high := EloFromScore(min(s+margin, 1))
+	return EloFromScore(s), (high - low) / 2
+}
+
+// SPRT is a sequential probability ratio test between the hypotheses that the
+// Elo difference is Elo0 (H0) or Elo1 (H1), with false positive rate Alpha and
+// false negative rate Beta.
+type SPRT struct {
+	Elo0  float64
+	Elo1  float64
+	Alpha float64
+	Beta  float64
+}
+
+type SPRTStatus int
+
+const (
+	SPRT_CONTINUE SPRTStatus = 0
+	SPRT_H0       SPRTStatus = 1 // accept H0, the Elo difference is Elo0
+	SPRT_H1       SPRTStatus = 2 // accept H1, the Elo difference is Elo1
+)
+
+// Returns the lower and upper bounds of the log likelihood ratio.
+func (t SPRT) Bounds() (float64, float64) {
+	lower := math.Log(t.Beta / (1 - t.Alpha))
+	upper := math.Log((1 - t.Beta) / t.Alpha)
+	return lower, upper
+}
+
+// Returns the log likelihood ratio of the result, using the normal
+// approximation of the generalised SPRT.
+func (t SPRT) LLR(r Result) float64 {
+	if r.Games() == 0 || r.variance() == 0 {
+		// not enough information to estimate the variance yet.
+		return 0
+	}
+	s0 := ScoreFromElo(t.Elo0)
+	s1 := ScoreFromElo(t.Elo1)
+	n := float64(r.Games())
+	return n * (s1 - s0) * (2*r.Score() - s0 - s1) / (2 * r.variance())
+}
+
+// Returns whether the test has accepted either hypothesis.
+func (t SPRT) Status(r Result) SPRTStatus {
+	llr := t.LLR(r)
+	lower, upper := t.Bounds()
+	switch {
+	case llr >= upper:
+		return SPRT_H1
+	case llr <= lower:
+		return SPRT_H0
+	default:
+		return SPRT_CONTINUE
+	}
+}
@@ -0,0 +1,59 @@
+package match
+
+import (
+	"math"
+	"testing"
+)
+
+func TestElo(t *testing.T) {
+	r := Result{Wins: 60, Draws: 20, Losses: 20}
+	elo, margin := r.Elo()
+	if math.Abs(elo-147.19) > 0.01 {
+		t.Errorf("expected elo 147.19, got %.2f", elo)
+	}
+	if margin <= 0 || margin > 100 {
+		t.Errorf("unexpected error margin %.2f", margin)
+	}
+
+	even := Result{Wins: 10, Draws: 10, Losses: 10}
+	if elo, _ := even.Elo(); elo != 0 {
+		t.Errorf("expected elo 0 for an even match, got %.2f", elo)
+	}
+
+	if elo, margin := (Result{Wins: 10}).Elo(); !math.IsInf(elo, 1) || !math.IsInf(margin, 1) {
+		t.Errorf("expected +Inf, +Inf when every game is won, got %.2f, %.2f", elo, margin)
+	}
+	if elo, margin := (Result{Losses: 10}).Elo(); !math.IsInf(elo, -1) || !math.IsInf(margin, 1) {
+		t.Errorf("expected -Inf, +Inf when every game is lost, got %.2f, %.2f", elo, margin)
+	}
+
+	for _, elo := range []float64{-200, -5, 0, 5, 200} {
+		if got := EloFromScore(ScoreFromElo(elo)); math.Abs(got-elo) > 1e-9 {
+			t.Errorf("elo round trip failed, %.2f != %.2f", got, elo)
+		}
+	}
+}
+
+func TestSPRT(t *testing.T) {
+	test := SPRT{Elo0: 0, Elo1: 5, Alpha: 0.05, Beta: 0.05}
+	lower, upper := test.Bounds()
+	if math.Abs(lower+2.944) > 0.001 || math.Abs(upper-2.944) > 0.001 {
+		t.Errorf("unexpected bounds [%.3f, %.3f]", lower, upper)
+	}
+
+	if status := test.Status(Result{Wins: 3, Draws: 4, Losses: 3}); status != SPRT_CONTINUE {
+		t.Errorf("expected test to continue, got %d", status)
+	}
+	if status := test.Status(Result{Wins: 4000, Draws: 4000, Losses: 3000}); status != SPRT_H1 {
+		t.Errorf("expected H1 to be accepted, got %d", status)
+	}
+	if status := test.Status(Result{Wins: 3000, Draws: 4000, Losses: 4000}); status != SPRT_H0 {
+		t.Errorf("expected H0 to be accepted, got %d", status)
+	}
+	if status := test.Status(Result{Wins: 0, Draws: 500, Losses: 100}); status != SPRT_H0 {
+		t.Errorf("expected H0 to be accepted for a patch that never wins, got %d", status)
+	}
+	if llr := test.LLR(Result{Draws: 10}); llr != 0 {
+		t.Errorf("expected LLR 0 when the variance is 0, got %.3f", llr)
+	}
+}
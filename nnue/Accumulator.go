@@ -0,0 +1,110 @@
+package nnue
+
+import (
+	"github.com/ethankuehler/gochess/chess"
+)
+
+// Accumulator holds the hidden layer of the network for both perspectives.
+// It is kept up to date with Add, Remove and Move as pieces change so only the
+// affected weight rows are touched, Refresh rebuilds it from scratch.
+type Accumulator struct {
+	net    *Network
+	values [2][]int16 // indexed by the perspective colour
+}
+
+func NewAccumulator(net *Network) *Accumulator {
+	a := &Accumulator{net: net}
+	a.values[chess.WHITE] = make([]int16, net.Hidden)
+	a.values[chess.BLACK] = make([]int16, net.Hidden)
+	a.Reset()
+	return a
+}
+
+// Returns the input index of a piece as seen by perspective. Each side sees
+// the board from its own side, so for black the board is flipped and the
+// colours are swapped.
+func FeatureIndex(perspective chess.Colour, colour chess.Colour, piece chess.Piece, shift chess.Shift) int {
+	side := 0
+	if colour != perspective {
+		side = 1
+	}
+	if perspective == chess.BLACK {
+		shift ^= 56
+	}
+	return (side*chess.BLACK_OFFSET+int(piece))*chess.SHIFT_SIZE + int(shift)
+}
+
+// Clears the accumulator back to the feature bias, an empty board.
+func (a *Accumulator) Reset() {
+	copy(a.values[chess.WHITE], a.net.FeatureBias)
+	copy(a.values[chess.BLACK], a.net.FeatureBias)
+}
+
+// Rebuilds the accumulator from every piece on the board.
+func (a *Accumulator) Refresh(b *chess.BoardState) {
+	a.Reset()
+	for _, colour := range []chess.Colour{chess.WHITE, chess.BLACK} {
+		for piece := chess.PAWN; piece <= chess.KING; piece++ {
+			for shift := range b.GetPieces(colour, piece).Squares() {
+				a.Add(colour, piece, shift)
+			}
+		}
+	}
+}
+
+// Adds a piece to the accumulator.
+func (a *Accumulator) Add(colour chess.Colour, piece chess.Piece, shift chess.Shift) {
+	for _, perspective := range []chess.Colour{chess.WHITE, chess.BLACK} {
+		addRow(a.values[perspective], a.row(FeatureIndex(perspective, colour, piece, shift)))
+	}
+}
+
+// Removes a piece from the accumulator.
+func (a *Accumulator) Remove(colour chess.Colour, piece chess.Piece, shift chess.Shift) {
+	for _, perspective := range []chess.Colour{chess.WHITE, chess.BLACK} {
+		subRow(a.values[perspective], a.row(FeatureIndex(perspective, colour, piece, shift)))
+	}
+}
+
+// Moves a piece between two squares, same as a Remove followed by an Add.
+func (a *Accumulator) Move(colour chess.Colour, piece chess.Piece, from, to chess.Shift) {
+	a.Remove(colour, piece, from)
+	a.Add(colour, piece, to)
+}
+
+// Copies the values of other into a, used to restore an accumulator on unmake.
+func (a *Accumulator) CopyFrom(other *Accumulator) {
+	copy(a.values[chess.WHITE], other.values[chess.WHITE])
+	copy(a.values[chess.BLACK], other.values[chess.BLACK])
+}
+
+// Returns the evaluation in centipawns for the side to move.
+func (a *Accumulator) Evaluate(turn chess.Colour) int {
+	return a.net.Output(a.values[turn], a.values[1-turn])
+}
+
+// Evaluates a board by building a fresh accumulator.
+func (net *Network) Evaluate(b *chess.BoardState) int {
+	a := NewAccumulator(net)
+	a.Refresh(b)
+	return a.Evaluate(b.Turn())
+}
+
+func (a *Accumulator) row(feature int) []int16 {
+	hidden := a.net.Hidden
+	return a.net.FeatureWeights[feature*hidden : (feature+1)*hidden]
+}
+
+func addRow(acc, row []int16) {
+	row = row[:len(acc)]
+	for i := range acc {
+		acc[i] += row[i]
+	}
+}
+
+func subRow(acc, row []int16) {
+	row = row[:len(acc)]
+	for i := range acc {
+		acc[i] -= row[i]
+	}
+}
@@ -0,0 +1,115 @@
+package nnue
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Quantisation constants, the feature transformer is scaled by QA and the
+// output layer by QB. SCALE turns the network output into centipawns.
+const (
+	QA    = 255
+	QB    = 64
+	SCALE = 400
+)
+
+// 12 pieces on 64 squares, see FeatureIndex.
+const INPUT_SIZE = 12 * 64
+
+// Network is a (768 -> HIDDEN)x2 -> 1 perspective network with int16 weights.
+// The hidden layer is shared between both perspectives and each side keeps its
+// own accumulator.
+type Network struct {
+	Hidden         int
+	FeatureWeights []int16 // INPUT_SIZE * Hidden, row major by feature
+	FeatureBias    []int16 // Hidden
+	OutputWeights  []int16 // 2 * Hidden, side to move first then the opponent
+	OutputBias     int16
+}
+
+// Creates a zeroed network with a hidden layer of the given size.
+func NewNetwork(hidden int) *Network {
+	return &Network{
+		Hidden:         hidden,
+		FeatureWeights: make([]int16, INPUT_SIZE*hidden),
+		FeatureBias:    make([]int16, hidden),
+		OutputWeights:  make([]int16, 2*hidden),
+	}
+}
+
+// Loads a network from a file, see ReadNetwork for the format.
+func LoadNetwork(file_name string) (*Network, error) {
+	file, err := os.Open(file_name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return ReadNetwork(file, info.Size())
+}
+
+// Reads a network of size bytes. The file is a flat list of little endian
+// int16s: feature weights, feature bias, output weights then output bias.
+// The hidden layer size is worked out from the length.
+func ReadNetwork(r io.Reader, size int64) (*Network, error) {
+	// INPUT_SIZE*H + H + 2*H + 1 values of 2 bytes each
+	values := size / 2
+	per_hidden := int64(INPUT_SIZE + 3)
+	if size%2 != 0 || values < 1 || (values-1)%per_hidden != 0 {
+		return nil, fmt.Errorf("invalid network size %d bytes", size)
+	}
+	hidden := int((values - 1) / per_hidden)
+	if hidden == 0 {
+		return nil, errors.New("invalid network, hidden layer is empty")
+	}
+
+	net := NewNetwork(hidden)
+	for _, data := range [][]int16{net.FeatureWeights, net.FeatureBias, net.OutputWeights} {
+		if err := binary.Read(r, binary.LittleEndian, data); err != nil {
+			return nil, fmt.Errorf("could not read network: %w", err)
+		}
+	}
+	if err := binary.Read(r, binary.LittleEndian, &net.OutputBias); err != nil {
+		return nil, fmt.Errorf("could not read network: %w", err)
+	}
+	return net, nil
+}
+
+// Writes the network in the format read by ReadNetwork.
+func (net *Network) Write(w io.Writer) error {
+	for _, data := range [][]int16{net.FeatureWeights, net.FeatureBias, net.OutputWeights} {
+		if err := binary.Write(w, binary.LittleEndian, data); err != nil {
+			return err
+		}
+	}
+	return binary.Write(w, binary.LittleEndian, net.OutputBias)
+}
+
+// Runs the output layer on a pair of accumulators and returns the evaluation in
+// centipawns for the side to move.
+func (net *Network) Output(us, them []int16) int {
+	sum := dotClipped(us, net.OutputWeights[:net.Hidden])
+	sum += dotClipped(them, net.OutputWeights[net.Hidden:])
+	// sum is scaled by QA*QB, add the bias at the same scale.
+	out := (sum + int64(net.OutputBias)*QA) * SCALE / (QA * QB)
+	return int(out)
+}
+
+// Sum of clipped ReLU activations times weights. Kept as a plain loop over two
+// equal length slices so the compiler can drop bounds checks.
+func dotClipped(acc, weights []int16) int64 {
+	weights = weights[:len(acc)]
+	sum := int64(0)
+	for i, v := range acc {
+		x := int32(min(max(v, 0), QA))
+		sum += int64(x * int32(weights[i]))
+	}
+	return sum
+}
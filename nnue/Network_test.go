@@ -0,0 +1,118 @@
+package nnue
+
+import (
+	"bytes"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/ethankuehler/gochess/chess"
+)
+
+func randomNetwork(hidden int) *Network {
+	r := rand.New(rand.NewPCG(1, 2))
+	net := NewNetwork(hidden)
+	for i := range net.FeatureWeights {
+		net.FeatureWeights[i] = int16(r.IntN(64) - 32)
+	}
+	for i := range net.FeatureBias {
+		net.FeatureBias[i] = int16(r.IntN(64))
+	}
+	for i := range net.OutputWeights {
+		net.OutputWeights[i] = int16(r.IntN(128) - 64)
+	}
+	net.OutputBias = 12
+	return net
+}
+
+func TestReadWriteNetwork(t *testing.T) {
+	net := randomNetwork(16)
+	var buffer bytes.Buffer
+	if err := net.Write(&buffer); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadNetwork(&buffer, int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Hidden != net.Hidden || got.OutputBias != net.OutputBias ||
+		!slices.Equal(got.FeatureWeights, net.FeatureWeights) ||
+		!slices.Equal(got.FeatureBias, net.FeatureBias) ||
+		!slices.Equal(got.OutputWeights, net.OutputWeights) {
+		t.Error("network did not match after round trip")
+	}
+
+	if _, err := ReadNetwork(&buffer, 11); err == nil {
+		t.Error("expected error for bad network size")
+	}
+}
+
+func TestIncrementalUpdate(t *testing.T) {
+	net := randomNetwork(32)
+	before, _ := chess.NewBoardFEN("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1")
+	after, _ := chess.NewBoardFEN("rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq - 0 1")
+
+	e2, _ := chess.ShiftFromAlg("e2")
+	e4, _ := chess.ShiftFromAlg("e4")
+	incremental := NewAccumulator(net)
+	incremental.Refresh(before)
+	incremental.Move(chess.WHITE, chess.PAWN, e2, e4)
+
+	full := NewAccumulator(net)
+	full.Refresh(after)
+
+	for _, perspective := range []chess.Colour{chess.WHITE, chess.BLACK} {
+		if !slices.Equal(incremental.values[perspective], full.values[perspective]) {
+			t.Errorf("accumulator for %d did not match a full refresh", perspective)
+		}
+	}
+	if incremental.Evaluate(chess.BLACK) != net.Evaluate(after) {
+		t.Error("incremental evaluation did not match full evaluation")
+	}
+}
+
+func TestEvaluateSymmetric(t *testing.T) {
+	net := randomNetwork(32)
+	white, _ := chess.NewBoardFEN("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1")
+	black, _ := chess.NewBoardFEN("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR b KQkq - 0 1")
+	if net.Evaluate(white) != net.Evaluate(black) {
+		t.Errorf("start position should evaluate the same for both sides, %d != %d",
+			net.Evaluate(white), net.Evaluate(black))
+	}
+}
+
+func BenchmarkRefresh(b *testing.B) {
+	net := randomNetwork(256)
+	board, _ := chess.NewBoardFEN("r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1")
+	acc := NewAccumulator(net)
+	b.ResetTimer()
+	for range b.N {
+		acc.Refresh(board)
+	}
+}
+
+func BenchmarkIncrementalMove(b *testing.B) {
+	net := randomNetwork(256)
+	board, _ := chess.NewBoardFEN("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1")
+	e2, _ := chess.ShiftFromAlg("e2")
+	e4, _ := chess.ShiftFromAlg("e4")
+	acc := NewAccumulator(net)
+	acc.Refresh(board)
+	b.ResetTimer()
+	for range b.N {
+		acc.Move(chess.WHITE, chess.PAWN, e2, e4)
+		acc.Move(chess.WHITE, chess.PAWN, e4, e2)
+	}
+}
+
+func BenchmarkEvaluate(b *testing.B) {
+	net := randomNetwork(256)
+	board, _ := chess.NewBoardFEN("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1")
+	acc := NewAccumulator(net)
+	acc.Refresh(board)
+	b.ResetTimer()
+	for range b.N {
+		acc.Evaluate(chess.WHITE)
+	}
+}
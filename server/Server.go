@@ -0,0 +1,59 @@
+// Package server exposes gochess over a local HTTP/JSON API.
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ethankuehler/gochess/chess"
+)
+
+// FENRequest is the body of a request that carries a position.
+type FENRequest struct {
+	FEN string `json:"fen"`
+}
+
+// FENResponse describes a valid position.
+type FENResponse struct {
+	FEN     string `json:"fen"`
+	Turn    string `json:"turn"`
+	InCheck bool   `json:"in_check"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Returns the handler for the API.
+//
+//	POST /fen  validates and normalises a FEN
+func NewHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /fen", handleFEN)
+	return mux
+}
+
+func handleFEN(w http.ResponseWriter, r *http.Request) {
+	var req FENRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{"invalid request body: " + err.Error()})
+		return
+	}
+	b, err := chess.NewBoardFEN(req.FEN)
+	if err != nil {
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{err.Error()})
+		return
+	}
+
+	turn := "w"
+	if b.Turn() == chess.BLACK {
+		turn = "b"
+	}
+	writeJSON(w, http.StatusOK, FENResponse{b.FEN(), turn, b.InCheck()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFEN(t *testing.T) {
+	srv := httptest.NewServer(NewHandler())
+	defer srv.Close()
+
+	post := func(body string) *http.Response {
+		resp, err := http.Post(srv.URL+"/fen", "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	resp := post(`{"fen": "4k3/8/8/8/8/8/8/4K2r   w - -"}`)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	var got FENResponse
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	expected := FENResponse{"4k3/8/8/8/8/8/8/4K2r w - - 0 1", "w", true}
+	if got != expected {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+
+	tests := []struct {
+		body   string
+		status int
+	}{
+		{`{"fen": "not a fen"}`, http.StatusUnprocessableEntity},
+		{`{"fen": `, http.StatusBadRequest},
+		{`{"fen": "8/8/8/8/8/8/8/8 w - e 0 1"}`, http.StatusUnprocessableEntity},
+		{`{"fen": "xxxxxxxx/8/8/8/8/8/8/8 w - - 0 1"}`, http.StatusUnprocessableEntity},
+		{`{"fen": "9/8/8/8/8/8/8/8 w - - 0 1"}`, http.StatusUnprocessableEntity},
+		{`{"fen": "7/8/8/8/8/8/8/9 w - - 0 1"}`, http.StatusUnprocessableEntity},
+	}
+	for _, test := range tests {
+		resp := post(test.body)
+		resp.Body.Close()
+		if resp.StatusCode != test.status {
+			t.Errorf("%s: expected %d, got %d", test.body, test.status, resp.StatusCode)
+		}
+	}
+
+	resp, err := http.Get(srv.URL + "/fen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405 for GET, got %d", resp.StatusCode)
+	}
+}